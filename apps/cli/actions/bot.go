@@ -2,12 +2,16 @@ package actions
 
 import (
 	"context"
+	"net/http"
 
 	"github.com/rs/zerolog"
 	"github.com/vinicius73/gear-feed/pkg/botworker"
 	"github.com/vinicius73/gear-feed/pkg/configurations"
+	"github.com/vinicius73/gear-feed/pkg/feed"
 	"github.com/vinicius73/gear-feed/pkg/model"
 	"github.com/vinicius73/gear-feed/pkg/storage/database"
+	"github.com/vinicius73/gear-feed/pkg/telegram"
+	"gopkg.in/telebot.v3"
 )
 
 func BotWorker(ctx context.Context) error {
@@ -30,20 +34,51 @@ func BotWorker(ctx context.Context) error {
 		return err
 	}
 
-	botSender, err := buildSender(SenderOptions{
+	var telegramBot *telebot.Bot
+
+	// on dry run, or without telegram, the bot commands are disabled
+	if !config.DryRun.Enabled && config.Telegram.Token != "" {
+		telegramBot, err = telegram.NewBot(config.Telegram)
+		if err != nil {
+			return err
+		}
+	}
+
+	senderOpts := SenderOptions{
 		Storage:  store,
 		Chats:    config.Telegram.Broadcast,
 		Telegram: config.Telegram,
-	})
+		Bot:      telegramBot,
+		DryRun:   config.DryRun,
+		Discord:  config.Discord,
+		Mastodon: config.Mastodon,
+	}
+
+	botSender, err := buildSender(senderOpts)
 	if err != nil {
 		return err
 	}
 
+	alertSender, err := buildAlertSender(senderOpts, config.Cron.Alerts.Chats)
+	if err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+
+	feed.Mount(mux, "/feeds", store, config.FeedConfig())
+
 	bot := botworker.New[model.Entry](botworker.BotOptions[model.Entry]{
-		Storage: store,
-		Sender:  botSender,
+		Storage:  store,
+		Sender:   botSender,
+		Alerts:   alertSender,
+		Factory:  buildFactory(senderOpts),
+		Telegram: telegramBot,
+		Mux:      mux,
 		Config: botworker.Config[model.Entry]{
-			Cron: config.Cron,
+			Cron:   config.Cron,
+			Admins: config.Telegram.Admins,
+			HTTP:   config.HTTP,
 		},
 	})
 
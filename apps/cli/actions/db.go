@@ -29,5 +29,6 @@ func Cleanup(ctx context.Context) error {
 	}.Run(ctx, tasks.TaskRunOptions[model.Entry]{
 		Storage: store,
 		Sender:  nil,
+		Stats:   nil,
 	})
 }
@@ -0,0 +1,67 @@
+package actions
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+	"github.com/vinicius73/gear-feed/pkg/configurations"
+	"github.com/vinicius73/gear-feed/pkg/feed"
+	"github.com/vinicius73/gear-feed/pkg/model"
+)
+
+type ExportFeedOptions struct {
+	Output  string
+	Formats []string
+	Scopes  []string
+	Limit   int
+}
+
+// ExportFeed writes the feeds of sent entries into the output directory.
+func ExportFeed(ctx context.Context, opt ExportFeedOptions) error {
+	config := configurations.Ctx(ctx)
+
+	formats := make([]feed.Format, len(opt.Formats))
+
+	for index, val := range opt.Formats {
+		format, err := feed.ParseFormat(val)
+		if err != nil {
+			return err
+		}
+
+		formats[index] = format
+	}
+
+	kinds := make([]feed.ScopeKind, len(opt.Scopes))
+
+	for index, val := range opt.Scopes {
+		kinds[index] = feed.ScopeKind(val)
+	}
+
+	store, db, err := buildDB[model.Entry](ctx, config)
+	if err != nil {
+		return err
+	}
+
+	defer db.Close()
+
+	cfg := config.FeedConfig()
+
+	if opt.Limit > 0 {
+		cfg.Limit = opt.Limit
+	}
+
+	files, err := feed.Export(ctx, feed.ExportOptions[model.Entry]{
+		Config:  cfg,
+		Storage: store,
+		Output:  opt.Output,
+		Formats: formats,
+		Kinds:   kinds,
+	})
+	if err != nil {
+		return err
+	}
+
+	zerolog.Ctx(ctx).Info().Str("output", opt.Output).Msgf("%d feed files exported", len(files))
+
+	return nil
+}
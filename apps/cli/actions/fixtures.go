@@ -0,0 +1,118 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/vinicius73/gear-feed/pkg/sources"
+	"github.com/vinicius73/gear-feed/pkg/sources/fixtures"
+	"github.com/vinicius73/gear-feed/pkg/support"
+	"github.com/vinicius73/gear-feed/pkg/support/apperrors"
+)
+
+var (
+	ErrSourceNotFound  = apperrors.Business("source %s not found", "SOURCES:NOT_FOUND")
+	ErrFixturesChanged = apperrors.Business("%d sources do not match the golden files", "FIXTURES:CHANGED")
+)
+
+type FixturesOptions struct {
+	Paths []string
+	Dir   string
+	// Names of the sources, all the sources with fixtures when empty.
+	Names []string
+	// Update rewrites the golden files with the replayed entries.
+	Update bool
+}
+
+func SnapshotSource(ctx context.Context, name string, opt FixturesOptions) error {
+	list, err := sources.Load(ctx, sources.LoadOptions{Paths: opt.Paths, Only: []string{name}})
+	if err != nil {
+		return err
+	}
+
+	if len(list) == 0 {
+		return ErrSourceNotFound.Msgf(name)
+	}
+
+	entries, err := fixtures.Snapshot(ctx, list[0], opt.Dir)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stdout, "saved %d entries of %s into %s\n", len(entries), name, opt.Dir)
+
+	return nil
+}
+
+func TestSources(ctx context.Context, opt FixturesOptions) error {
+	names := opt.Names
+
+	if len(names) == 0 {
+		var err error
+
+		if names, err = fixtures.Sources(opt.Dir); err != nil {
+			return err
+		}
+	}
+
+	list, err := sources.Load(ctx, sources.LoadOptions{Paths: opt.Paths, Only: names})
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		if !support.Contains(list.Names(), name) {
+			return ErrSourceNotFound.Msgf(name)
+		}
+	}
+
+	failures := 0
+
+	for _, source := range list {
+		if opt.Update {
+			entries, err := fixtures.Replay(ctx, source, opt.Dir)
+			if err == nil {
+				err = fixtures.WriteGolden(filepath.Join(opt.Dir, source.Name), entries)
+			}
+
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(os.Stdout, "UPDATED %s (%d entries)\n", source.Name, len(entries))
+
+			continue
+		}
+
+		diff, err := fixtures.Test(ctx, source, opt.Dir)
+		if err != nil {
+			failures++
+
+			fmt.Fprintf(os.Stdout, "ERROR %s: %s\n", source.Name, err)
+
+			continue
+		}
+
+		if len(diff) == 0 {
+			fmt.Fprintf(os.Stdout, "PASS %s\n", source.Name)
+
+			continue
+		}
+
+		failures++
+
+		fmt.Fprintf(os.Stdout, "FAIL %s\n", source.Name)
+
+		for _, line := range diff {
+			fmt.Fprintf(os.Stdout, "  %s\n", line)
+		}
+	}
+
+	if failures > 0 {
+		return ErrFixturesChanged.Msgf(failures)
+	}
+
+	return nil
+}
@@ -2,6 +2,7 @@ package actions
 
 import (
 	"context"
+	"time"
 
 	"github.com/vinicius73/gear-feed/pkg/configurations"
 	"github.com/vinicius73/gear-feed/pkg/model"
@@ -14,6 +15,7 @@ type LoadOptions struct {
 	To           int64
 	SendResumeTo []int64
 	Limit        int
+	MaxAge       time.Duration
 	Sources      sources.LoadOptions
 }
 
@@ -36,6 +38,9 @@ func Load(ctx context.Context, opt LoadOptions) error {
 		Chats:    []int64{opt.To},
 		Storage:  store,
 		Telegram: config.Telegram,
+		DryRun:   config.DryRun,
+		Discord:  config.Discord,
+		Mastodon: config.Mastodon,
 	})
 	if err != nil {
 		return err
@@ -43,11 +48,13 @@ func Load(ctx context.Context, opt LoadOptions) error {
 
 	return tasks.SendLastEntries[model.Entry]{
 		Limit:        opt.Limit,
+		MaxAge:       opt.MaxAge,
 		Sources:      opt.Sources,
 		SendResumeTo: opt.SendResumeTo,
 	}.
 		Run(ctx, tasks.TaskRunOptions[model.Entry]{
 			Storage: store,
 			Sender:  botSender,
+			Stats:   nil,
 		})
 }
@@ -1,26 +1,186 @@
 package actions
 
 import (
+	"github.com/vinicius73/gear-feed/pkg/configurations"
+	"github.com/vinicius73/gear-feed/pkg/discord"
+	"github.com/vinicius73/gear-feed/pkg/mastodon"
 	"github.com/vinicius73/gear-feed/pkg/model"
 	"github.com/vinicius73/gear-feed/pkg/sender"
 	"github.com/vinicius73/gear-feed/pkg/storage"
 	"github.com/vinicius73/gear-feed/pkg/telegram"
+	"gopkg.in/telebot.v3"
 )
 
 type SenderOptions struct {
 	Chats    []int64
 	Storage  storage.Storage[model.Entry]
 	Telegram telegram.Config
+	// Bot is optional, a new one is created from Telegram config when nil.
+	Bot      *telebot.Bot
+	DryRun   configurations.DryRun
+	Discord  discord.Config
+	Mastodon mastodon.Config
 }
 
 func buildSender(opt SenderOptions) (sender.Serder[model.Entry], error) {
-	bot, err := telegram.NewBot(opt.Telegram)
-	if err != nil {
-		return nil, err
+	if opt.DryRun.Enabled {
+		serder, err := sender.NewDryRunSerder(sender.DryRunOptions[model.Entry]{
+			Chats:   opt.Chats,
+			Dir:     opt.DryRun.Dir,
+			Store:   opt.DryRun.Store,
+			Storage: opt.Storage,
+			Output:  nil,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return sender.Instrument[model.Entry](serder, "dry_run"), nil
+	}
+
+	// without a telegram token, discord is used as delivery channel
+	if opt.Telegram.Token == "" && opt.Discord.Enabled() {
+		return sender.Instrument[model.Entry](sender.NewDiscordSerder(sender.DiscordOptions[model.Entry]{
+			Config:  opt.Discord,
+			Storage: opt.Storage,
+		}), string(sender.DestinationDiscord)), nil
 	}
 
-	return sender.NewTelegramSerder(bot, sender.TelegramOptions[model.Entry]{
+	bot := opt.Bot
+
+	if bot == nil {
+		var err error
+
+		bot, err = telegram.NewBot(opt.Telegram)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return sender.Instrument[model.Entry](sender.NewTelegramSerder(bot, sender.TelegramOptions[model.Entry]{
 		Storage: opt.Storage,
 		Chats:   opt.Chats,
+	}), string(sender.DestinationTelegram)), nil
+}
+
+// buildAlertSender builds a telegram sender for the alert chats, nil without chats or telegram.
+func buildAlertSender(opt SenderOptions, chats []int64) (sender.Serder[model.Entry], error) {
+	if len(chats) == 0 {
+		return nil, nil //nolint:nilnil
+	}
+
+	if opt.DryRun.Enabled {
+		return sender.NewDryRunSerder(sender.DryRunOptions[model.Entry]{
+			Chats:       chats,
+			Dir:         opt.DryRun.Dir,
+			Destination: "",
+			Store:       false,
+			Storage:     nil,
+			Output:      nil,
+		})
+	}
+
+	if opt.Telegram.Token == "" {
+		return nil, nil //nolint:nilnil
+	}
+
+	return buildTelegramTarget(&opt, sender.Destination{
+		ID:       "",
+		Type:     sender.DestinationTelegram,
+		Chats:    chats,
+		Webhooks: nil,
+	})
+}
+
+// buildFactory builds the senders of tasks with destinations.
+func buildFactory(opt SenderOptions) sender.Factory[model.Entry] {
+	return func(destinations []sender.Destination) (sender.Serder[model.Entry], error) {
+		targets := make([]sender.Target[model.Entry], len(destinations))
+
+		for index, destination := range destinations {
+			// the webhooks are part of the delivery keys
+			if destination.Type == sender.DestinationDiscord && len(destination.Webhooks) == 0 {
+				destination.Webhooks = opt.Discord.Webhooks
+			}
+
+			serder, err := buildTarget(&opt, destination)
+			if err != nil {
+				return nil, err
+			}
+
+			targets[index] = sender.Target[model.Entry]{
+				Destination: destination,
+				Serder:      sender.Instrument(serder, destination.Key()),
+			}
+		}
+
+		store := opt.Storage
+
+		if opt.DryRun.Enabled && !opt.DryRun.Store {
+			store = nil
+		}
+
+		return sender.NewMultiSerder(sender.MultiOptions[model.Entry]{
+			Targets: targets,
+			Storage: store,
+		}), nil
+	}
+}
+
+// buildTarget builds a sender without storage, entries are stored by the multi sender.
+func buildTarget(opt *SenderOptions, destination sender.Destination) (sender.Serder[model.Entry], error) {
+	if err := destination.Validate(); err != nil {
+		return nil, err
+	}
+
+	if opt.DryRun.Enabled {
+		return sender.NewDryRunSerder(sender.DryRunOptions[model.Entry]{
+			Chats:       destination.Chats,
+			Dir:         opt.DryRun.Dir,
+			Destination: destination.Key(),
+			Store:       false,
+			Storage:     nil,
+			Output:      nil,
+		})
+	}
+
+	switch destination.Type {
+	case sender.DestinationDiscord:
+		config := opt.Discord
+
+		if len(destination.Webhooks) > 0 {
+			config.Webhooks = destination.Webhooks
+		}
+
+		return sender.NewDiscordSerder(sender.DiscordOptions[model.Entry]{
+			Config:  config,
+			Storage: nil,
+		}), nil
+	case sender.DestinationMastodon:
+		return sender.NewMastodonSerder(sender.MastodonOptions[model.Entry]{
+			Config:  opt.Mastodon,
+			Storage: nil,
+		})
+	case sender.DestinationTelegram:
+		return buildTelegramTarget(opt, destination)
+	}
+
+	return nil, sender.ErrUnknownDestination.Msgf(destination.Type)
+}
+
+func buildTelegramTarget(opt *SenderOptions, destination sender.Destination) (sender.Serder[model.Entry], error) {
+	// the bot is shared by all telegram destinations
+	if opt.Bot == nil {
+		bot, err := telegram.NewBot(opt.Telegram)
+		if err != nil {
+			return nil, err
+		}
+
+		opt.Bot = bot
+	}
+
+	return sender.NewTelegramSerder(opt.Bot, sender.TelegramOptions[model.Entry]{
+		Chats:   destination.Chats,
+		Storage: nil,
 	}), nil
 }
@@ -0,0 +1,32 @@
+package actions
+
+import (
+	"context"
+
+	"github.com/vinicius73/gear-feed/pkg/configurations"
+	"github.com/vinicius73/gear-feed/pkg/model"
+	"github.com/vinicius73/gear-feed/pkg/storage"
+)
+
+// SourcesHealth returns the stored health of the sources, indexed by name.
+func SourcesHealth(ctx context.Context, names []string) (map[string]storage.SourceHealth, error) {
+	result := map[string]storage.SourceHealth{}
+
+	store, db, err := buildDB[model.Entry](ctx, configurations.Ctx(ctx))
+	if err != nil {
+		return result, err
+	}
+
+	defer db.Close()
+
+	list, err := store.SourcesHealth(names...)
+	if err != nil {
+		return result, err
+	}
+
+	for _, health := range list {
+		result[health.Source] = health
+	}
+
+	return result, nil
+}
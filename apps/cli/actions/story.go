@@ -78,6 +78,9 @@ func SendStories(ctx context.Context, opt SendStoriesOptions) error {
 		Chats:    []int64{opt.To},
 		Storage:  store,
 		Telegram: config.Telegram,
+		DryRun:   config.DryRun,
+		Discord:  config.Discord,
+		Mastodon: config.Mastodon,
 	})
 	if err != nil {
 		return err
@@ -92,5 +95,6 @@ func SendStories(ctx context.Context, opt SendStoriesOptions) error {
 		Run(ctx, tasks.TaskRunOptions[model.Entry]{
 			Storage: store,
 			Sender:  botSender,
+			Stats:   nil,
 		})
 }
@@ -0,0 +1,159 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/vinicius73/gear-feed/pkg/configurations"
+	"github.com/vinicius73/gear-feed/pkg/cron"
+	"github.com/vinicius73/gear-feed/pkg/model"
+	"github.com/vinicius73/gear-feed/pkg/storage"
+	"gopkg.in/yaml.v3"
+)
+
+type RunTaskOptions struct {
+	Name string
+	// Print shows the resolved task instead of running it.
+	Print bool
+	// DryRun renders the messages instead of sending them, like the global --dry-run.
+	DryRun bool
+}
+
+// RunTask executes a configured cron task on demand.
+func RunTask(ctx context.Context, opt RunTaskOptions) error {
+	config := configurations.Ctx(ctx)
+	logger := zerolog.Ctx(ctx).With().Str("task", opt.Name).Logger()
+	ctx = logger.WithContext(ctx)
+
+	if opt.Print {
+		return printTask(config, opt.Name)
+	}
+
+	store, db, err := buildDB[model.Entry](ctx, config)
+	if err != nil {
+		return err
+	}
+
+	defer db.Close()
+
+	dryRun := config.DryRun
+
+	if opt.DryRun {
+		dryRun.Enabled = true
+	}
+
+	senderOpts := SenderOptions{
+		Storage:  store,
+		Chats:    config.Telegram.Broadcast,
+		Telegram: config.Telegram,
+		Bot:      nil,
+		DryRun:   dryRun,
+		Discord:  config.Discord,
+		Mastodon: config.Mastodon,
+	}
+
+	botSender, err := buildSender(senderOpts)
+	if err != nil {
+		return err
+	}
+
+	alertSender, err := buildAlertSender(senderOpts, config.Cron.Alerts.Chats)
+	if err != nil {
+		return err
+	}
+
+	runner := cron.New[model.Entry](cron.RunnerOptions[model.Entry]{
+		Storage: store,
+		Sender:  botSender,
+		Alerts:  alertSender,
+		Factory: buildFactory(senderOpts),
+		Config:  config.Cron,
+	})
+
+	if err = runner.Run(ctx, opt.Name); err != nil {
+		return err
+	}
+
+	logger.Info().Msg("Task finished")
+
+	return nil
+}
+
+func printTask(config *configurations.AppConfig, name string) error {
+	runner := cron.New[model.Entry](cron.RunnerOptions[model.Entry]{
+		Storage: nil,
+		Sender:  nil,
+		Alerts:  nil,
+		Factory: nil,
+		Config:  config.Cron,
+	})
+
+	task, err := runner.Find(name)
+	if err != nil {
+		return err
+	}
+
+	out, err := yaml.Marshal(task)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(os.Stdout, "# %s (nothing was executed)\n%s", name, out)
+
+	return err
+}
+
+type TaskHistoryOptions struct {
+	Task  string
+	Since time.Duration
+	Limit int
+}
+
+// TaskHistory prints the last task runs.
+func TaskHistory(ctx context.Context, opt TaskHistoryOptions) error {
+	config := configurations.Ctx(ctx)
+
+	store, db, err := buildDB[model.Entry](ctx, config)
+	if err != nil {
+		return err
+	}
+
+	defer db.Close()
+
+	runs, err := store.TaskRuns(storage.FindTaskRunsOptions{
+		Task:  opt.Task,
+		Since: time.Now().Add(-opt.Since),
+		Limit: opt.Limit,
+	})
+	if err != nil {
+		return err
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(writer, "STARTED\tTASK\tSCHEDULE\tSTATUS\tDURATION\tCOUNTERS\tERROR")
+
+	for _, run := range runs {
+		schedule := run.Schedule
+
+		if schedule == "" {
+			schedule = "on demand"
+		}
+
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			run.StartedAt.Local().Format(time.DateTime),
+			run.Task,
+			schedule,
+			run.Status,
+			run.Duration().Round(time.Second),
+			run.FormatCounters(),
+			run.Error,
+		)
+	}
+
+	return writer.Flush()
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"github.com/urfave/cli/v2"
+	"github.com/vinicius73/gear-feed/apps/cli/actions"
+)
+
+func feedCMD() *cli.Command {
+	export := &cli.Command{
+		Name:        "export",
+		Description: `Export the sent entries as RSS, Atom and JSON feeds.`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "output",
+				Aliases:  []string{"o"},
+				Usage:    "Directory where the feeds are written",
+				Required: true,
+			},
+			&cli.StringSliceFlag{
+				Name:    "format",
+				Aliases: []string{"f"},
+				Usage:   "Feed formats (rss, atom, json), all when empty",
+			},
+			&cli.StringSliceFlag{
+				Name:  "scope",
+				Usage: "Feed scopes (global, dirs, sources), all when empty",
+			},
+			&cli.IntFlag{
+				Name:    "limit",
+				Aliases: []string{"l"},
+				Usage:   "Limit the number of entries of each feed",
+			},
+		},
+		Action: func(cmd *cli.Context) error {
+			return actions.ExportFeed(cmd.Context, actions.ExportFeedOptions{
+				Output:  cmd.String("output"),
+				Formats: cmd.StringSlice("format"),
+				Scopes:  cmd.StringSlice("scope"),
+				Limit:   cmd.Int("limit"),
+			})
+		},
+	}
+
+	return &cli.Command{
+		Name:        "feed",
+		Description: `Outbound feeds of sent entries.`,
+		Subcommands: []*cli.Command{export},
+	}
+}
@@ -45,6 +45,8 @@ func main() {
 			botCMD(),
 			dbCMD(),
 			storiesCMD(),
+			taskCMD(),
+			feedCMD(),
 		},
 		EnableBashCompletion: true,
 	}
@@ -95,6 +97,12 @@ func beforeRun(cmd *cli.Context) error {
 		appConfig.Debug = true
 	}
 
+	appConfig.DryRun = configurations.DryRun{
+		Enabled: cmd.Bool("dry-run"),
+		Dir:     cmd.String("dry-run-dir"),
+		Store:   cmd.Bool("dry-run-store"),
+	}
+
 	cmd.Context = appConfig.WithContext(cmd.Context)
 
 	support.SetupLogger(appConfig.Logger.Level, appConfig.Logger.Format, appConfig.Tags())
@@ -136,5 +144,18 @@ func buildFlags() []cli.Flag {
 			Value: "",
 			Usage: "store logs in a file",
 		},
+		&cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "render messages instead of sending them to telegram",
+		},
+		&cli.StringFlag{
+			Name:  "dry-run-dir",
+			Value: "",
+			Usage: "store dry run messages and media in a directory instead of stdout",
+		},
+		&cli.BoolFlag{
+			Name:  "dry-run-store",
+			Usage: "mark entries as sent on dry run",
+		},
 	}
 }
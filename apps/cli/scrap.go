@@ -49,11 +49,16 @@ func scrapCMD() *cli.Command {
 				Usage:   "Send the loaded data to the specified channel",
 				Aliases: []string{"r"},
 			},
+			&cli.DurationFlag{
+				Name:  "max-age",
+				Usage: "Ignore entries published before this period (0 disables)",
+			},
 		},
 		Action: func(cmd *cli.Context) error {
 			return actions.Load(cmd.Context, actions.LoadOptions{
 				To:           cmd.Int64("to"),
 				Limit:        cmd.Int("limit"),
+				MaxAge:       cmd.Duration("max-age"),
 				SendResumeTo: cmd.Int64Slice("send-resume-to"),
 				Sources: sources.LoadOptions{
 					Only:  cmd.StringSlice("only"),
@@ -1,10 +1,15 @@
 package main
 
 import (
+	"fmt"
+
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/rs/zerolog"
 	"github.com/urfave/cli/v2"
+	"github.com/vinicius73/gear-feed/apps/cli/actions"
+	"github.com/vinicius73/gear-feed/pkg/scraper"
 	"github.com/vinicius73/gear-feed/pkg/sources"
+	"github.com/vinicius73/gear-feed/pkg/tui/playground"
 	layout "github.com/vinicius73/gear-feed/pkg/tui/sources"
 )
 
@@ -35,10 +40,16 @@ func sourcesCMD() *cli.Command {
 
 			logger := zerolog.Ctx(cmd.Context)
 
+			// the list is still useful without the health
+			health, err := actions.SourcesHealth(cmd.Context, list.Names())
+			if err != nil {
+				logger.Warn().Err(err).Msg("failed to load sources health")
+			}
+
 			ctx := logger.WithContext(cmd.Context)
 
 			p := tea.NewProgram(
-				layout.NewModel(ctx, list),
+				layout.NewModel(ctx, list, health),
 				tea.WithAltScreen(),
 				tea.WithContext(ctx),
 			)
@@ -50,9 +61,134 @@ func sourcesCMD() *cli.Command {
 		},
 	}
 
+	validate := &cli.Command{
+		Name:  "validate",
+		Usage: "Check the source definitions, including the disabled ones",
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:     "sources",
+				Aliases:  []string{"s"},
+				Usage:    "Validate sources from the specified directories, their subdirectories are not read",
+				Required: true,
+			},
+		},
+		Action: func(cmd *cli.Context) error {
+			problems, err := sources.Validate(cmd.Context, cmd.StringSlice("sources"))
+			if err != nil {
+				return err
+			}
+
+			for _, problem := range problems {
+				fmt.Fprintln(cmd.App.Writer, problem.String())
+			}
+
+			if len(problems) > 0 {
+				return sources.ErrInvalidSources.Msgf(len(problems))
+			}
+
+			fmt.Fprintln(cmd.App.Writer, "all source definitions are valid")
+
+			return nil
+		},
+	}
+
+	fixturesFlags := []cli.Flag{
+		&cli.StringSliceFlag{
+			Name:     "sources",
+			Aliases:  []string{"s"},
+			Usage:    "Load sources from the specified paths",
+			Required: true,
+		},
+		&cli.StringFlag{
+			Name:    "fixtures",
+			Aliases: []string{"f"},
+			Usage:   "Directory of the saved responses and golden files",
+			Value:   "fixtures",
+		},
+	}
+
+	snapshot := &cli.Command{
+		Name:      "snapshot",
+		Usage:     "Save the responses and the extracted entries of a source",
+		ArgsUsage: "<name>",
+		Flags:     fixturesFlags,
+		Action: func(cmd *cli.Context) error {
+			if cmd.Args().Len() != 1 {
+				return cli.ShowSubcommandHelp(cmd)
+			}
+
+			return actions.SnapshotSource(cmd.Context, cmd.Args().First(), actions.FixturesOptions{
+				Paths:  cmd.StringSlice("sources"),
+				Dir:    cmd.String("fixtures"),
+				Names:  nil,
+				Update: false,
+			})
+		},
+	}
+
+	test := &cli.Command{
+		Name:      "test",
+		Usage:     "Replay the saved responses and compare the entries with the golden files",
+		ArgsUsage: "[names...]",
+		Flags: append(fixturesFlags, &cli.BoolFlag{
+			Name:  "update",
+			Usage: "Rewrite the golden files with the replayed entries",
+		}),
+		Action: func(cmd *cli.Context) error {
+			return actions.TestSources(cmd.Context, actions.FixturesOptions{
+				Paths:  cmd.StringSlice("sources"),
+				Dir:    cmd.String("fixtures"),
+				Names:  cmd.Args().Slice(),
+				Update: cmd.Bool("update"),
+			})
+		},
+	}
+
+	playgroundCMD := &cli.Command{
+		Name:      "playground",
+		Usage:     "Edit the selectors of a new source and preview the extracted entries",
+		ArgsUsage: "<url>",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "parser",
+				Aliases: []string{"p"},
+				Usage:   "Parser of the page (HTML, XML, JSON, RSS or ATOM)",
+				Value:   scraper.HTML,
+			},
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Usage:   "File written on save, defaults to <name>.yml",
+			},
+		},
+		Action: func(cmd *cli.Context) error {
+			if cmd.Args().Len() != 1 {
+				return cli.ShowSubcommandHelp(cmd)
+			}
+
+			source, err := playground.NewSource(cmd.Args().First(), cmd.String("parser"))
+			if err != nil {
+				return err
+			}
+
+			p := tea.NewProgram(
+				playground.New(cmd.Context, playground.Options{
+					Source: source,
+					Output: cmd.String("output"),
+				}),
+				tea.WithAltScreen(),
+				tea.WithContext(cmd.Context),
+			)
+
+			_, err = p.Run()
+
+			return err
+		},
+	}
+
 	return &cli.Command{
 		Name:        "sources",
 		Description: "Interact with sources",
-		Subcommands: []*cli.Command{list},
+		Subcommands: []*cli.Command{list, validate, snapshot, test, playgroundCMD},
 	}
 }
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v2"
+	"github.com/vinicius73/gear-feed/apps/cli/actions"
+	"github.com/vinicius73/gear-feed/pkg/cron"
+)
+
+const defaultHistoryLimit = 50
+
+var taskNames = []string{
+	string(cron.TaskSendLastEntries),
+	string(cron.TaskSendLastStories),
+	string(cron.TaskBackup),
+	string(cron.TaskCleanup),
+	string(cron.TaskDispatchQueue),
+}
+
+func taskCMD() *cli.Command {
+	run := &cli.Command{
+		Name:        "run",
+		Description: `Run a configured cron task now, using the same options as the scheduler.`,
+		ArgsUsage:   "<" + strings.Join(taskNames, "|") + ">",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "print",
+				Usage: "Print the resolved task instead of running it",
+			},
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "Run the task rendering the messages instead of sending them",
+			},
+		},
+		Action: func(cmd *cli.Context) error {
+			name := cmd.Args().First()
+
+			if name == "" {
+				return cli.Exit("Task name is required: "+strings.Join(taskNames, ", "), 1)
+			}
+
+			// the flags after the task name are not parsed, they must not run the task silently
+			if cmd.Args().Len() > 1 {
+				return cli.Exit("Unexpected arguments after the task name: "+strings.Join(cmd.Args().Tail(), " ")+
+					", the flags must come before it", 1)
+			}
+
+			return actions.RunTask(cmd.Context, actions.RunTaskOptions{
+				Name:   name,
+				Print:  cmd.Bool("print"),
+				DryRun: cmd.Bool("dry-run"),
+			})
+		},
+	}
+
+	history := &cli.Command{
+		Name:        "history",
+		Description: `Show the last runs of the cron tasks, scheduled or on demand.`,
+		ArgsUsage:   "[" + strings.Join(taskNames, "|") + "]",
+		Flags: []cli.Flag{
+			&cli.DurationFlag{
+				Name:    "since",
+				Aliases: []string{"s"},
+				Usage:   "Show runs started in this period",
+				Value:   time.Hour * 24,
+			},
+			&cli.IntFlag{
+				Name:    "limit",
+				Aliases: []string{"l"},
+				Usage:   "Maximum number of runs",
+				Value:   defaultHistoryLimit,
+			},
+		},
+		Action: func(cmd *cli.Context) error {
+			return actions.TaskHistory(cmd.Context, actions.TaskHistoryOptions{
+				Task:  cmd.Args().First(),
+				Since: cmd.Duration("since"),
+				Limit: cmd.Int("limit"),
+			})
+		},
+	}
+
+	return &cli.Command{
+		Name:        "task",
+		Description: `Cron tasks related commands.`,
+		Subcommands: []*cli.Command{run, history},
+	}
+}
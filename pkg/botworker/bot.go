@@ -2,37 +2,58 @@ package botworker
 
 import (
 	"context"
+	"net/http"
 	"time"
 
+	"github.com/rs/zerolog"
+
 	"github.com/vinicius73/gear-feed/pkg/cron"
+	"github.com/vinicius73/gear-feed/pkg/httpserver"
 	"github.com/vinicius73/gear-feed/pkg/model"
 	"github.com/vinicius73/gear-feed/pkg/sender"
 	"github.com/vinicius73/gear-feed/pkg/storage"
+	"gopkg.in/telebot.v3"
 )
 
 const stopTimeout = time.Second * 30
 
 type Config[T model.IEntry] struct {
-	Cron cron.TasksConfig[T]
+	Cron   cron.TasksConfig[T]
+	Admins []int64
+	HTTP   httpserver.Config
 }
 
 type BotOptions[T model.IEntry] struct {
-	Config  Config[T]
-	Sender  sender.Serder[T]
-	Storage storage.Storage[T]
+	Config Config[T]
+	Sender sender.Serder[T]
+	// Alerts sends the failure alerts of the tasks.
+	Alerts   sender.Serder[T]
+	Factory  sender.Factory[T]
+	Storage  storage.Storage[T]
+	Telegram *telebot.Bot
+	// Mux receives extra routes, health, metrics and status routes are added when HTTP is enabled.
+	Mux *http.ServeMux
 }
 
 type Bot[T model.IEntry] struct {
-	config  Config[T]
-	sender  sender.Serder[T]
-	storage storage.Storage[T]
+	config   Config[T]
+	sender   sender.Serder[T]
+	alerts   sender.Serder[T]
+	factory  sender.Factory[T]
+	storage  storage.Storage[T]
+	telegram *telebot.Bot
+	mux      *http.ServeMux
 }
 
 func New[T model.IEntry](opts BotOptions[T]) Bot[T] {
 	return Bot[T]{
-		config:  opts.Config,
-		sender:  opts.Sender,
-		storage: opts.Storage,
+		config:   opts.Config,
+		sender:   opts.Sender,
+		alerts:   opts.Alerts,
+		factory:  opts.Factory,
+		storage:  opts.Storage,
+		telegram: opts.Telegram,
+		mux:      opts.Mux,
 	}
 }
 
@@ -42,8 +63,14 @@ func (b Bot[T]) Run(ctx context.Context) error {
 		return err
 	}
 
+	stopListen := b.listen(ctx, runner)
+
+	b.serve(ctx, runner)
+
 	<-ctx.Done()
 
+	stopListen()
+
 	ctx, cancel := context.WithTimeout(context.Background(), stopTimeout)
 
 	defer cancel()
@@ -56,6 +83,8 @@ func (b Bot[T]) schedule(ctx context.Context) (cron.Runner[T], error) {
 	runner := cron.New[T](cron.RunnerOptions[T]{
 		Storage: b.storage,
 		Sender:  b.sender,
+		Alerts:  b.alerts,
+		Factory: b.factory,
 		Config:  b.config.Cron,
 	})
 
@@ -65,3 +94,23 @@ func (b Bot[T]) schedule(ctx context.Context) (cron.Runner[T], error) {
 
 	return runner, nil
 }
+
+func (b Bot[T]) serve(ctx context.Context, runner cron.Runner[T]) {
+	if !b.config.HTTP.Enabled() {
+		return
+	}
+
+	mux := b.mux
+
+	if mux == nil {
+		mux = http.NewServeMux()
+	}
+
+	mount(mux, runner)
+
+	go func() {
+		if err := httpserver.Run(ctx, b.config.HTTP, mux); err != nil {
+			zerolog.Ctx(ctx).Error().Err(err).Msg("HTTP server failed")
+		}
+	}()
+}
@@ -0,0 +1,323 @@
+package botworker
+
+import (
+	"context"
+	"html"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/vinicius73/gear-feed/pkg/cron"
+	"github.com/vinicius73/gear-feed/pkg/model"
+	"github.com/vinicius73/gear-feed/pkg/sender"
+	"github.com/vinicius73/gear-feed/pkg/sources"
+	"github.com/vinicius73/gear-feed/pkg/storage"
+	"github.com/vinicius73/gear-feed/pkg/telegram"
+	"gopkg.in/telebot.v3"
+)
+
+const (
+	defaultLastLimit = 5
+	maxLastLimit     = 30
+	historyLimit     = 15
+	historyPeriod    = time.Hour * 24
+	historyErrorSize = 200
+	timeLayout       = "02/01 15:04"
+)
+
+var commandList = []telebot.Command{
+	{Text: "status", Description: "Show scheduled tasks"},
+	{Text: "run", Description: "Run a task now: /run <task>"},
+	{Text: "sources", Description: "List enabled sources"},
+	{Text: "pause", Description: "Pause scheduled tasks"},
+	{Text: "resume", Description: "Resume scheduled tasks"},
+	{Text: "last", Description: "Show last sent entries: /last [limit]"},
+	{Text: "history", Description: "Show task runs of the last 24h: /history [task]"},
+}
+
+//nolint:containedctx
+type commands[T model.IEntry] struct {
+	ctx     context.Context
+	runner  cron.Runner[T]
+	storage storage.Storage[T]
+	sources sources.LoadOptions
+}
+
+func (b Bot[T]) listen(ctx context.Context, runner cron.Runner[T]) func() {
+	logger := zerolog.Ctx(ctx).With().Str("component", "bot:commands").Logger()
+
+	if b.telegram == nil || len(b.config.Admins) == 0 {
+		logger.Warn().Msg("No admins defined, bot commands are disabled")
+
+		return func() {}
+	}
+
+	cmds := commands[T]{
+		ctx:     ctx,
+		runner:  runner,
+		storage: b.storage,
+		sources: b.config.Cron.SendLastEntries.Config.Sources,
+	}
+
+	group := b.telegram.Group()
+	group.Use(telegram.WithLogger(logger), telegram.OnlyChats(b.config.Admins))
+
+	group.Handle("/status", cmds.status)
+	group.Handle("/run", cmds.run)
+	group.Handle("/sources", cmds.listSources)
+	group.Handle("/pause", cmds.pause)
+	group.Handle("/resume", cmds.resume)
+	group.Handle("/last", cmds.last)
+	group.Handle("/history", cmds.history)
+
+	if err := b.telegram.SetCommands(commandList); err != nil {
+		logger.Warn().Err(err).Msg("Fail to register bot commands")
+	}
+
+	go b.telegram.Start()
+
+	logger.Info().Ints64("admins", b.config.Admins).Msg("Listening bot commands")
+
+	return b.telegram.Stop
+}
+
+func (c commands[T]) status(tx telebot.Context) error {
+	var builder strings.Builder
+
+	builder.WriteString(sender.BuildMsgHeader())
+	builder.WriteString("\n\n⏯ <b>State:</b> ")
+
+	if c.runner.Paused() {
+		builder.WriteString("<code>paused</code>")
+	} else {
+		builder.WriteString("<code>running</code>")
+	}
+
+	builder.WriteString("\n\n📅 <b>Jobs</b>")
+
+	for _, job := range c.runner.Jobs() {
+		builder.WriteString("\n- <b>")
+		builder.WriteString(job.Task)
+		builder.WriteString("</b> next <code>")
+		builder.WriteString(formatTime(job.NextRun))
+		builder.WriteString("</code> last <code>")
+		builder.WriteString(formatTime(job.LastRun))
+		builder.WriteString("</code>")
+
+		if !job.Result.StartedAt.IsZero() {
+			builder.WriteString(" " + resultIcon(job.Result))
+		}
+	}
+
+	builder.WriteString(sender.BuildMsgFooter())
+
+	return tx.Send(builder.String(), telebot.ModeHTML)
+}
+
+func (c commands[T]) run(tx telebot.Context) error {
+	name := strings.TrimSpace(tx.Message().Payload)
+
+	if name == "" {
+		return tx.Send(c.taskNames(), telebot.ModeHTML)
+	}
+
+	if _, err := c.runner.Find(name); err != nil {
+		return tx.Send("⚠️ "+html.EscapeString(err.Error())+"\n\n"+c.taskNames(), telebot.ModeHTML)
+	}
+
+	if err := tx.Send("⏳ Running <code>"+html.EscapeString(name)+"</code>", telebot.ModeHTML); err != nil {
+		return err
+	}
+
+	logger, _ := tx.Get(telegram.LoggerKey).(zerolog.Logger)
+
+	go func() {
+		startedAt := time.Now()
+
+		err := c.runner.Run(logger.WithContext(c.ctx), name)
+		msg := "✅ <code>" + name + "</code> finished in <code>" + time.Since(startedAt).Round(time.Second).String() + "</code>"
+
+		if err != nil {
+			logger.Error().Err(err).Str("task", name).Msg("Fail to run task on demand")
+
+			msg = "❌ <code>" + name + "</code> failed\n<code>" + html.EscapeString(err.Error()) + "</code>"
+		}
+
+		if err := tx.Send(msg, telebot.ModeHTML); err != nil {
+			logger.Error().Err(err).Msg("Fail to reply")
+		}
+	}()
+
+	return nil
+}
+
+func (c commands[T]) listSources(tx telebot.Context) error {
+	list, err := sources.Load(c.ctx, c.sources)
+	if err != nil {
+		return err
+	}
+
+	var builder strings.Builder
+
+	builder.WriteString("📚 <b>Sources</b> <code>")
+	builder.WriteString(strconv.Itoa(len(list)))
+	builder.WriteString("</code>\n")
+
+	for _, source := range list {
+		builder.WriteString("\n- <b>")
+		builder.WriteString(html.EscapeString(source.Name))
+		builder.WriteString("</b> <code>")
+		builder.WriteString(strings.ToLower(source.Parser))
+		builder.WriteString("</code>")
+
+		if source.SupportStories {
+			builder.WriteString(" 🎬")
+		}
+	}
+
+	return tx.Send(builder.String(), telebot.ModeHTML)
+}
+
+func (c commands[T]) pause(tx telebot.Context) error {
+	c.runner.Pause()
+
+	return tx.Send("⏸ Scheduled tasks paused")
+}
+
+func (c commands[T]) resume(tx telebot.Context) error {
+	c.runner.Resume()
+
+	return tx.Send("▶️ Scheduled tasks resumed")
+}
+
+func (c commands[T]) last(tx telebot.Context) error {
+	limit := defaultLastLimit
+
+	if payload := strings.TrimSpace(tx.Message().Payload); payload != "" {
+		val, err := strconv.Atoi(payload)
+		if err != nil || val < 1 {
+			return tx.Send("⚠️ Invalid limit: " + payload)
+		}
+
+		limit = min(val, maxLastLimit)
+	}
+
+	entries, err := c.storage.FindLatest(limit)
+	if err != nil {
+		return err
+	}
+
+	if len(entries) == 0 {
+		return tx.Send("📭 No entries sent yet")
+	}
+
+	var builder strings.Builder
+
+	builder.WriteString("🗞 <b>Last entries</b>\n")
+
+	for _, entry := range entries {
+		builder.WriteString("\n- <a href=\"")
+		builder.WriteString(html.EscapeString(entry.Link()))
+		builder.WriteString("\">")
+		builder.WriteString(html.EscapeString(entry.Text()))
+		builder.WriteString("</a> #")
+		builder.WriteString(entry.Source())
+	}
+
+	return tx.Send(builder.String(), telebot.ModeHTML, telebot.NoPreview)
+}
+
+func (c commands[T]) history(tx telebot.Context) error {
+	runs, err := c.storage.TaskRuns(storage.FindTaskRunsOptions{
+		Task:  strings.TrimSpace(tx.Message().Payload),
+		Since: time.Now().Add(-historyPeriod),
+		Limit: historyLimit,
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(runs) == 0 {
+		return tx.Send("📭 No task runs in the last 24h")
+	}
+
+	var builder strings.Builder
+
+	builder.WriteString("🗂 <b>Task runs</b>\n")
+
+	for _, run := range runs {
+		builder.WriteString("\n")
+		builder.WriteString(resultIcon(run))
+		builder.WriteString(" <code>")
+		builder.WriteString(formatTime(run.StartedAt))
+		builder.WriteString("</code> <b>")
+		builder.WriteString(run.Task)
+		builder.WriteString("</b> <code>")
+		builder.WriteString(run.Duration().Round(time.Second).String())
+		builder.WriteString("</code>")
+
+		if run.Schedule == "" {
+			builder.WriteString(" 👆")
+		}
+
+		if counters := run.FormatCounters(); counters != "" {
+			builder.WriteString("\n    <i>")
+			builder.WriteString(counters)
+			builder.WriteString("</i>")
+		}
+
+		if run.Error != "" {
+			builder.WriteString("\n    <code>")
+			builder.WriteString(html.EscapeString(truncate(run.Error, historyErrorSize)))
+			builder.WriteString("</code>")
+		}
+	}
+
+	return tx.Send(builder.String(), telebot.ModeHTML)
+}
+
+func (c commands[T]) taskNames() string {
+	var builder strings.Builder
+
+	builder.WriteString("🧰 <b>Tasks</b>")
+
+	for _, task := range c.runner.Tasks() {
+		builder.WriteString("\n- <code>")
+		builder.WriteString(task.Name())
+		builder.WriteString("</code>")
+	}
+
+	return builder.String()
+}
+
+func resultIcon(run storage.TaskRun) string {
+	switch run.Status {
+	case storage.TaskRunFailure:
+		return "❌"
+	case storage.TaskRunRunning:
+		return "⏳"
+	case storage.TaskRunSuccess:
+		return "✅"
+	}
+
+	return ""
+}
+
+func truncate(text string, size int) string {
+	runes := []rune(text)
+
+	if len(runes) <= size {
+		return text
+	}
+
+	return string(runes[:size]) + "…"
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "never"
+	}
+
+	return t.Format(timeLayout)
+}
@@ -0,0 +1,116 @@
+package botworker
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/vinicius73/gear-feed/pkg/cron"
+	"github.com/vinicius73/gear-feed/pkg/metrics"
+	"github.com/vinicius73/gear-feed/pkg/model"
+)
+
+type statusResponse struct {
+	State string        `json:"state"`
+	Jobs  []jobResponse `json:"jobs"`
+}
+
+type jobResponse struct {
+	Name       string          `json:"name"`
+	Task       string          `json:"task"`
+	Running    bool            `json:"running"`
+	NextRun    *time.Time      `json:"next_run"`
+	LastRun    *time.Time      `json:"last_run"`
+	LastResult *resultResponse `json:"last_result"`
+}
+
+type resultResponse struct {
+	Status     string         `json:"status"`
+	ErrorCode  string         `json:"error_code,omitempty"`
+	Error      string         `json:"error,omitempty"`
+	StartedAt  time.Time      `json:"started_at"`
+	FinishedAt time.Time      `json:"finished_at"`
+	Duration   string         `json:"duration"`
+	Counters   map[string]int `json:"counters,omitempty"`
+}
+
+// mount registers the health, metrics and status routes.
+func mount[T model.IEntry](mux *http.ServeMux, runner cron.Runner[T]) {
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, _ *http.Request) {
+		writeText(w, http.StatusOK, "ok")
+	})
+
+	mux.HandleFunc("GET /readyz", func(w http.ResponseWriter, _ *http.Request) {
+		if !runner.Running() {
+			writeText(w, http.StatusServiceUnavailable, "scheduler is not running")
+
+			return
+		}
+
+		writeText(w, http.StatusOK, "ok")
+	})
+
+	mux.Handle("GET /metrics", metrics.Default.Handler())
+
+	mux.HandleFunc("GET /status", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+
+		_ = encoder.Encode(buildStatus(runner))
+	})
+}
+
+func buildStatus[T model.IEntry](runner cron.Runner[T]) statusResponse {
+	response := statusResponse{
+		State: "running",
+		Jobs:  []jobResponse{},
+	}
+
+	if runner.Paused() {
+		response.State = "paused"
+	}
+
+	for _, job := range runner.Jobs() {
+		item := jobResponse{
+			Name:       job.Name,
+			Task:       job.Task,
+			Running:    job.Running,
+			NextRun:    optionalTime(job.NextRun),
+			LastRun:    optionalTime(job.LastRun),
+			LastResult: nil,
+		}
+
+		if !job.Result.StartedAt.IsZero() {
+			item.LastResult = &resultResponse{
+				Status:     string(job.Result.Status),
+				ErrorCode:  job.Result.ErrorCode,
+				Error:      job.Result.Error,
+				StartedAt:  job.Result.StartedAt,
+				FinishedAt: job.Result.FinishedAt,
+				Duration:   job.Result.Duration().Round(time.Millisecond).String(),
+				Counters:   job.Result.Counters,
+			}
+		}
+
+		response.Jobs = append(response.Jobs, item)
+	}
+
+	return response
+}
+
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+
+	return &t
+}
+
+func writeText(w http.ResponseWriter, status int, body string) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(status)
+
+	_, _ = w.Write([]byte(body + "\n"))
+}
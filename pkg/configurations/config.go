@@ -4,7 +4,12 @@ import (
 	"context"
 
 	"github.com/vinicius73/gear-feed/pkg/cron"
+	"github.com/vinicius73/gear-feed/pkg/discord"
+	"github.com/vinicius73/gear-feed/pkg/feed"
+	"github.com/vinicius73/gear-feed/pkg/httpserver"
+	"github.com/vinicius73/gear-feed/pkg/mastodon"
 	"github.com/vinicius73/gear-feed/pkg/model"
+	"github.com/vinicius73/gear-feed/pkg/sources"
 	"github.com/vinicius73/gear-feed/pkg/storage/database"
 	"github.com/vinicius73/gear-feed/pkg/telegram"
 )
@@ -13,11 +18,50 @@ type ctxKey struct{}
 
 type AppConfig struct {
 	Debug    bool                          `fig:"-"        yaml:"-"`
+	DryRun   DryRun                        `fig:"-"        yaml:"-"`
 	Timezone string                        `fig:"timezone" yaml:"timezone"`
 	Logger   Logger                        `fig:"logger"   yaml:"logger"`
 	Telegram telegram.Config               `fig:"telegram" yaml:"telegram"`
+	Discord  discord.Config                `fig:"discord"  yaml:"discord"`
+	Mastodon mastodon.Config               `fig:"mastodon" yaml:"mastodon"`
 	Storage  database.Options              `fig:"storage"  yaml:"storage"`
 	Cron     cron.TasksConfig[model.Entry] `fig:"cron"     yaml:"cron"`
+	Feed     feed.Config                   `fig:"feed"     yaml:"feed"`
+	HTTP     httpserver.Config             `fig:"http"     yaml:"http"`
+}
+
+// FeedConfig returns the feed config, using the send_last_entries sources when it has none.
+func (c AppConfig) FeedConfig() feed.Config {
+	cfg := c.Feed
+
+	if len(cfg.Sources.Paths) == 0 {
+		cfg.Sources = c.Cron.SendLastEntries.Config.Sources
+	}
+
+	return cfg
+}
+
+// stripParams loads the strip_params of all sources used by the tasks and feeds.
+func (c AppConfig) stripParams(ctx context.Context) (map[string][]string, error) {
+	paths := append([]string{}, c.Cron.SendLastEntries.Config.Sources.Paths...)
+	paths = append(paths, c.Cron.SendLastStories.Config.Sources.Paths...)
+	paths = append(paths, c.Feed.Sources.Paths...)
+
+	list, err := sources.Load(ctx, sources.LoadOptions{Paths: paths, Only: nil})
+	if err != nil {
+		return nil, err
+	}
+
+	return list.StripParams(), nil
+}
+
+// DryRun replaces the telegram sender by one that only renders the messages.
+type DryRun struct {
+	Enabled bool
+	// Dir stores the messages and media as files, stdout is used when empty.
+	Dir string
+	// Store marks the entries as sent.
+	Store bool
 }
 
 type Logger struct {
@@ -93,7 +93,8 @@ func applyDefaults(cfg AppConfig) (AppConfig, error) {
 		cfg.Telegram.Token = os.Getenv("TELEGRAM_TOKEN")
 	}
 
-	if cfg.Telegram.Token == "" {
+	// discord can be used as the only delivery channel
+	if cfg.Telegram.Token == "" && !cfg.Discord.Enabled() {
 		return cfg, ErrMissingTelegramToken
 	}
 
@@ -114,7 +115,15 @@ func applyDefaults(cfg AppConfig) (AppConfig, error) {
 		cfg.Storage.TTL = defaultTTL
 	}
 
+	cfg.Storage.StripParams = cfg.stripParams
+
 	cfg.Cron.Timezone, _ = time.LoadLocation(cfg.Timezone)
+	cfg.Cron.SendLastEntries.Config.Queue.QuietHours.Location = cfg.Cron.Timezone
+	cfg.Cron.DispatchQueue.Config.QuietHours = cfg.Cron.SendLastEntries.Config.Queue.QuietHours
+
+	if len(cfg.Cron.Alerts.Chats) == 0 {
+		cfg.Cron.Alerts.Chats = cfg.Telegram.Admins
+	}
 
 	if cfg.Cron.Backup.Config.Base != "" && !filepath.IsAbs(cfg.Cron.Backup.Config.Base) {
 		cfg.Cron.Backup.Config.Base = path.Join(pwd, cfg.Cron.Backup.Config.Base)
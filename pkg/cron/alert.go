@@ -0,0 +1,146 @@
+package cron
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/vinicius73/gear-feed/pkg/sender"
+	"github.com/vinicius73/gear-feed/pkg/storage"
+)
+
+const (
+	defaultAlertThreshold = 1
+	defaultAlertCooldown  = time.Hour * 6
+)
+
+// AlertConfig defines how failures of scheduled tasks are reported.
+type AlertConfig struct {
+	// Chats receive the alerts, telegram admins by default. Alerting is disabled without chats.
+	Chats []int64 `fig:"chats" yaml:"chats"`
+	// Threshold is the number of consecutive failures before the first alert.
+	Threshold int `fig:"threshold" yaml:"threshold"`
+	// Cooldown avoids repeating an alert, it is sent again sooner when the error changes.
+	Cooldown time.Duration `fig:"cooldown" yaml:"cooldown"`
+}
+
+func (c AlertConfig) Enabled() bool {
+	return len(c.Chats) > 0
+}
+
+// Alerter tracks the consecutive failures of each task, deciding when an alert must be sent.
+type Alerter struct {
+	config AlertConfig
+	mu     sync.Mutex
+	tasks  map[string]*failures
+}
+
+type failures struct {
+	count     int
+	since     time.Time
+	key       string
+	alertedAt time.Time
+}
+
+func NewAlerter(config AlertConfig) *Alerter {
+	if config.Threshold < 1 {
+		config.Threshold = defaultAlertThreshold
+	}
+
+	if config.Cooldown <= 0 {
+		config.Cooldown = defaultAlertCooldown
+	}
+
+	return &Alerter{config: config, mu: sync.Mutex{}, tasks: map[string]*failures{}}
+}
+
+// Check registers the run, returning the alert to send, if any.
+// A recovery alert is returned when a task that triggered an alert succeeds.
+func (a *Alerter) Check(run storage.TaskRun, now time.Time) (sender.Alert, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	state, found := a.tasks[run.Task]
+
+	if run.Status != storage.TaskRunFailure {
+		delete(a.tasks, run.Task)
+
+		if !found || state.alertedAt.IsZero() {
+			return sender.Alert{}, false
+		}
+
+		return sender.Alert{
+			Task:      run.Task,
+			ErrorCode: "",
+			Error:     "",
+			Failures:  state.count,
+			Since:     state.since,
+			Recovered: true,
+		}, true
+	}
+
+	if !found {
+		state = &failures{count: 0, since: run.StartedAt, key: "", alertedAt: time.Time{}}
+		a.tasks[run.Task] = state
+	}
+
+	state.count++
+
+	if state.count < a.config.Threshold {
+		return sender.Alert{}, false
+	}
+
+	// errors without code are compared by their message
+	key := run.ErrorCode
+
+	if key == "" {
+		key = run.Error
+	}
+
+	if !state.alertedAt.IsZero() && state.key == key && now.Sub(state.alertedAt) < a.config.Cooldown {
+		return sender.Alert{}, false
+	}
+
+	state.key = key
+	state.alertedAt = now
+
+	return sender.Alert{
+		Task:      run.Task,
+		ErrorCode: run.ErrorCode,
+		Error:     run.Error,
+		Failures:  state.count,
+		Since:     state.since,
+		Recovered: false,
+	}, true
+}
+
+// alert notifies the operators about failures of scheduled tasks.
+// On demand runs are not alerted, but their success ends the failure streak.
+func (r Runner[T]) alert(ctx context.Context, run storage.TaskRun) {
+	if !r.config.Alerts.Enabled() || (run.Schedule == "" && run.Status == storage.TaskRunFailure) {
+		return
+	}
+
+	alert, ok := r.state.alerter.Check(run, time.Now())
+	if !ok {
+		return
+	}
+
+	logger := zerolog.Ctx(ctx).With().Str("task", run.Task).Logger()
+
+	// alerts are private, they are never sent by the delivery channels
+	if r.alerts == nil {
+		logger.Warn().Msg("No telegram sender for alerts, skipping")
+
+		return
+	}
+
+	err := r.alerts.SendAlert(ctx, sender.SendAlertOptions{
+		Alert: alert,
+		Chats: r.config.Alerts.Chats,
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("Fail to send alert")
+	}
+}
@@ -0,0 +1,69 @@
+package cron_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vinicius73/gear-feed/pkg/cron"
+	"github.com/vinicius73/gear-feed/pkg/storage"
+)
+
+func failedRun(code string, startedAt time.Time) storage.TaskRun {
+	return storage.TaskRun{
+		Task:      "send_last_entries",
+		Schedule:  "0 * * * *",
+		Status:    storage.TaskRunFailure,
+		StartedAt: startedAt,
+		ErrorCode: code,
+		Error:     code + ": fail",
+	}
+}
+
+func TestAlerterCheck(t *testing.T) {
+	t.Parallel()
+
+	alerter := cron.NewAlerter(cron.AlertConfig{
+		Chats:     []int64{10},
+		Threshold: 2,
+		Cooldown:  time.Hour * 6,
+	})
+
+	now := time.Date(2024, 5, 10, 10, 0, 0, 0, time.UTC)
+
+	// below the threshold
+	_, ok := alerter.Check(failedRun("SENDER:FAIL_TO_SEND", now), now)
+	assert.False(t, ok)
+
+	alert, ok := alerter.Check(failedRun("SENDER:FAIL_TO_SEND", now.Add(time.Hour)), now.Add(time.Hour))
+	assert.True(t, ok)
+	assert.Equal(t, 2, alert.Failures)
+	assert.Equal(t, "SENDER:FAIL_TO_SEND", alert.ErrorCode)
+	assert.Equal(t, now, alert.Since)
+	assert.False(t, alert.Recovered)
+
+	// same error during the cooldown
+	_, ok = alerter.Check(failedRun("SENDER:FAIL_TO_SEND", now.Add(time.Hour*2)), now.Add(time.Hour*2))
+	assert.False(t, ok)
+
+	// another error is reported
+	alert, ok = alerter.Check(failedRun("DB:FailedToCreateEntry", now.Add(time.Hour*3)), now.Add(time.Hour*3))
+	assert.True(t, ok)
+	assert.Equal(t, 4, alert.Failures)
+
+	// the same error after the cooldown
+	_, ok = alerter.Check(failedRun("DB:FailedToCreateEntry", now.Add(time.Hour*9)), now.Add(time.Hour*9))
+	assert.True(t, ok)
+
+	alert, ok = alerter.Check(storage.TaskRun{
+		Task:   "send_last_entries",
+		Status: storage.TaskRunSuccess,
+	}, now.Add(time.Hour*10))
+	assert.True(t, ok)
+	assert.True(t, alert.Recovered)
+	assert.Equal(t, 5, alert.Failures)
+
+	// a success without previous alerts is not reported
+	_, ok = alerter.Check(storage.TaskRun{Task: "send_last_entries", Status: storage.TaskRunSuccess}, now)
+	assert.False(t, ok)
+}
@@ -2,6 +2,8 @@ package cron
 
 import (
 	"context"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-co-op/gocron"
@@ -12,28 +14,52 @@ import (
 	"github.com/vinicius73/gear-feed/pkg/sender"
 	"github.com/vinicius73/gear-feed/pkg/storage"
 	"github.com/vinicius73/gear-feed/pkg/support"
+	"github.com/vinicius73/gear-feed/pkg/support/apperrors"
 	"github.com/vinicius73/gear-feed/pkg/tasks"
 )
 
+var (
+	ErrTaskNotFound = apperrors.Business("task not found: %s", "CRON:TASK_NOT_FOUND")
+	ErrNoFactory    = apperrors.Business("task %s has destinations, but no sender factory", "CRON:NO_FACTORY")
+)
+
 type TasksConfig[T model.IEntry] struct {
 	Timezone        *time.Location                    `fig:"-"                 yaml:"-"`
 	SendLastEntries Task[T, tasks.SendLastEntries[T]] `fig:"send_last_entries" yaml:"send_last_entries"`
 	SendLastStories Task[T, tasks.SendLastStories[T]] `fig:"send_last_stories" yaml:"send_last_stories"`
 	Backup          Task[T, tasks.Backup[T]]          `fig:"backup"            yaml:"backup"`
 	Cleanup         Task[T, tasks.Cleanup[T]]         `fig:"cleanup"           yaml:"cleanup"`
+	DispatchQueue   Task[T, tasks.DispatchQueue[T]]   `fig:"dispatch_queue"    yaml:"dispatch_queue"`
+	Alerts          AlertConfig                       `fig:"alerts"            yaml:"alerts"`
 }
 
 type Runner[T model.IEntry] struct {
 	storage   storage.Storage[T]
 	sender    sender.Serder[T]
+	alerts    sender.Serder[T]
+	factory   sender.Factory[T]
 	config    TasksConfig[T]
 	scheduler *gocron.Scheduler
+	state     *runnerState
+}
+
+type runnerState struct {
+	paused atomic.Bool
+	// avoid scheduled and on demand executions running at same time
+	lock sync.Mutex
+	// results keeps the last run of each task
+	results sync.Map
+	alerter *Alerter
 }
 
 type RunnerOptions[T model.IEntry] struct {
 	Config  TasksConfig[T]
 	Storage storage.Storage[T]
 	Sender  sender.Serder[T]
+	// Alerts sends the failure alerts to the alert chats, they are skipped when nil.
+	Alerts sender.Serder[T]
+	// Factory builds the sender of tasks with destinations.
+	Factory sender.Factory[T]
 }
 
 func New[T model.IEntry](opts RunnerOptions[T]) Runner[T] {
@@ -45,10 +71,131 @@ func New[T model.IEntry](opts RunnerOptions[T]) Runner[T] {
 		config:    opts.Config,
 		storage:   opts.Storage,
 		sender:    opts.Sender,
+		alerts:    opts.Alerts,
+		factory:   opts.Factory,
 		scheduler: scheduler,
+		state: &runnerState{
+			paused:  atomic.Bool{},
+			lock:    sync.Mutex{},
+			results: sync.Map{},
+			alerter: NewAlerter(opts.Config.Alerts),
+		},
 	}
 }
 
+// Tasks returns all configured tasks.
+func (r Runner[T]) Tasks() []ScheduleTask[T] {
+	return []ScheduleTask[T]{
+		r.config.SendLastEntries,
+		r.config.SendLastStories,
+		r.config.Backup,
+		r.config.Cleanup,
+		r.config.DispatchQueue,
+	}
+}
+
+// Find a task by name.
+func (r Runner[T]) Find(name string) (ScheduleTask[T], error) {
+	for _, task := range r.Tasks() {
+		if task.Name() == name {
+			return task, nil
+		}
+	}
+
+	return nil, ErrTaskNotFound.Msgf(name)
+}
+
+// Run a task on demand, ignoring its schedules and the paused state.
+func (r Runner[T]) Run(ctx context.Context, name string) error {
+	task, err := r.Find(name)
+	if err != nil {
+		return err
+	}
+
+	logger := zerolog.Ctx(ctx).With().Str("task", task.Name()).Logger()
+
+	logger.Info().Msg("Running task on demand")
+
+	return r.Exec(logger.WithContext(ctx), task)
+}
+
+// Exec runs the task with the same options used by the scheduler.
+func (r Runner[T]) Exec(ctx context.Context, task ScheduleTask[T]) error {
+	return r.execute(ctx, task, "")
+}
+
+// execute runs the task and records its run, schedule is empty for on demand executions.
+func (r Runner[T]) execute(ctx context.Context, task ScheduleTask[T], schedule string) error {
+	r.state.lock.Lock()
+	defer r.state.lock.Unlock()
+
+	stats := tasks.NewStats()
+	run := r.startRun(ctx, task.Name(), schedule)
+
+	opts, err := r.RunOptions(task)
+	if err == nil {
+		opts.Stats = stats
+
+		if opts.Sender != nil {
+			opts.Sender = sender.Observe(opts.Sender, stats.Observe)
+		}
+
+		err = task.Run(ctx, opts)
+	}
+
+	r.finishRun(ctx, run, stats, err)
+
+	return err
+}
+
+// RunOptions builds the options used to run the task.
+func (r Runner[T]) RunOptions(task ScheduleTask[T]) (tasks.TaskRunOptions[T], error) {
+	opts := tasks.TaskRunOptions[T]{
+		Storage: r.storage,
+		Sender:  nil,
+		Stats:   nil,
+	}
+
+	// the queue is filled by send_last_entries, its entries go to the same destinations
+	if task.Name() == r.config.DispatchQueue.Name() {
+		task = r.config.SendLastEntries
+	}
+
+	destinations := task.Targets()
+
+	if len(destinations) == 0 {
+		opts.Sender = r.sender.WithChats(task.Chats())
+
+		return opts, nil
+	}
+
+	if r.factory == nil {
+		return opts, ErrNoFactory.Msgf(task.Name())
+	}
+
+	serder, err := r.factory(destinations)
+	if err != nil {
+		return opts, err
+	}
+
+	opts.Sender = serder
+
+	return opts, nil
+}
+
+// Pause skips scheduled executions until Resume is called.
+func (r Runner[T]) Pause() {
+	r.state.paused.Store(true)
+}
+
+func (r Runner[T]) Resume() {
+	r.state.paused.Store(false)
+}
+
+func (r Runner[T]) Paused() bool {
+	return r.state.paused.Load()
+}
+
 func (r Runner[T]) Start(ctx context.Context) error {
 	r.scheduler.Clear()
 
@@ -56,14 +203,7 @@ func (r Runner[T]) Start(ctx context.Context) error {
 
 	logger.Info().Msg("Starting cron tasks")
 
-	tasks := []ScheduleTask[T]{
-		r.config.SendLastEntries,
-		r.config.SendLastStories,
-		r.config.Backup,
-		r.config.Cleanup,
-	}
-
-	for _, task := range tasks {
+	for _, task := range r.Tasks() {
 		err := r.register(ctx, task)
 		if err != nil {
 			return err
@@ -77,6 +217,11 @@ func (r Runner[T]) Start(ctx context.Context) error {
 	return nil
 }
 
+// Running reports if the scheduler is started.
+func (r Runner[T]) Running() bool {
+	return r.scheduler.IsRunning()
+}
+
 func (r Runner[T]) Stop(_ context.Context) error {
 	r.scheduler.Clear()
 	r.scheduler.Stop()
@@ -96,7 +241,7 @@ func (r Runner[T]) register(ctx context.Context, task ScheduleTask[T]) error {
 	}
 
 	for _, schedule := range schedules {
-		job, err := r.scheduler.Cron(schedule).Do(r.exec, ctx, task)
+		job, err := r.scheduler.Cron(schedule).Do(r.exec, ctx, task, schedule)
 		if err != nil {
 			return err
 		}
@@ -124,18 +269,19 @@ func (r Runner[T]) register(ctx context.Context, task ScheduleTask[T]) error {
 	return nil
 }
 
-func (r Runner[T]) exec(ctx context.Context, task ScheduleTask[T]) {
+func (r Runner[T]) exec(ctx context.Context, task ScheduleTask[T], schedule string) {
 	logger := zerolog.Ctx(ctx).With().Str("task", task.Name()).Logger()
 	ctx = logger.WithContext(ctx)
 
-	logger.Info().Msg("Running task")
+	if r.Paused() {
+		logger.Warn().Msg("Runner is paused, skipping task")
 
-	opts := tasks.TaskRunOptions[T]{
-		Storage: r.storage,
-		Sender:  r.sender.WithChats(task.Chats()),
+		return
 	}
 
-	err := task.Run(ctx, opts)
+	logger.Info().Msg("Running task")
+
+	err := r.execute(ctx, task, schedule)
 	if err != nil {
 		logger.
 			Error().
@@ -0,0 +1,34 @@
+package cron_test
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vinicius73/gear-feed/pkg/cron"
+	"github.com/vinicius73/gear-feed/pkg/model"
+	"github.com/vinicius73/gear-feed/pkg/sender"
+	"github.com/vinicius73/gear-feed/pkg/tasks"
+)
+
+func TestRunOptionsDispatchQueue(t *testing.T) {
+	t.Parallel()
+
+	serder, err := sender.NewDryRunSerder(sender.DryRunOptions[model.Entry]{Output: io.Discard})
+	require.NoError(t, err)
+
+	config := cron.TasksConfig[model.Entry]{}
+	config.SendLastEntries.ChatIDs = []int64{10}
+	config.DispatchQueue.ChatIDs = []int64{20}
+
+	runner := cron.New(cron.RunnerOptions[model.Entry]{Config: config, Sender: serder})
+
+	task, err := runner.Find(tasks.DispatchQueue[model.Entry]{}.Name())
+	require.NoError(t, err)
+
+	// the queued entries go to the destinations of the task that queued them
+	opts, err := runner.RunOptions(task)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"telegram:10"}, opts.Sender.Destinations())
+}
@@ -0,0 +1,66 @@
+package cron
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/vinicius73/gear-feed/pkg/metrics"
+	"github.com/vinicius73/gear-feed/pkg/storage"
+	"github.com/vinicius73/gear-feed/pkg/support/apperrors"
+	"github.com/vinicius73/gear-feed/pkg/tasks"
+)
+
+// startRun records the task as running, so interrupted runs are also visible.
+func (r Runner[T]) startRun(ctx context.Context, task, schedule string) storage.TaskRun {
+	run := storage.TaskRun{
+		ID:         0,
+		Task:       task,
+		Schedule:   schedule,
+		Status:     storage.TaskRunRunning,
+		StartedAt:  time.Now(),
+		FinishedAt: time.Time{},
+		ErrorCode:  "",
+		Error:      "",
+		Counters:   map[string]int{},
+	}
+
+	return r.storeRun(ctx, run)
+}
+
+func (r Runner[T]) finishRun(ctx context.Context, run storage.TaskRun, stats *tasks.Stats, err error) {
+	run.FinishedAt = time.Now()
+	run.Counters = stats.Counters()
+	run.Status = storage.TaskRunSuccess
+
+	if err != nil {
+		run.Status = storage.TaskRunFailure
+		run.ErrorCode = apperrors.Code(err)
+		run.Error = err.Error()
+	}
+
+	run = r.storeRun(ctx, run)
+
+	r.state.results.Store(run.Task, run)
+
+	metrics.TaskRuns.Inc(run.Task, string(run.Status))
+	metrics.TaskDuration.Observe(run.Duration().Seconds(), run.Task)
+
+	r.alert(ctx, run)
+}
+
+// storeRun keeps the history, failing to store it does not stop the task.
+func (r Runner[T]) storeRun(ctx context.Context, run storage.TaskRun) storage.TaskRun {
+	if r.storage == nil {
+		return run
+	}
+
+	stored, err := r.storage.StoreTaskRun(run)
+	if err != nil {
+		zerolog.Ctx(ctx).Warn().Err(err).Str("task", run.Task).Msg("Fail to store task run")
+
+		return run
+	}
+
+	return stored
+}
@@ -0,0 +1,58 @@
+package cron
+
+import (
+	"sort"
+	"time"
+
+	"github.com/vinicius73/gear-feed/pkg/storage"
+)
+
+type JobStatus struct {
+	Name    string
+	Task    string
+	NextRun time.Time
+	LastRun time.Time
+	Running bool
+	// Result of the last execution of the task, scheduled or on demand.
+	Result storage.TaskRun
+}
+
+// LastResult of the task since the runner was created, false when it never ran.
+func (r Runner[T]) LastResult(task string) (storage.TaskRun, bool) {
+	val, ok := r.state.results.Load(task)
+	if !ok {
+		return storage.TaskRun{}, false
+	}
+
+	result, ok := val.(storage.TaskRun)
+
+	return result, ok
+}
+
+// Jobs returns the state of registered jobs, sorted by next run.
+func (r Runner[T]) Jobs() []JobStatus {
+	jobs := r.scheduler.Jobs()
+	result := make([]JobStatus, 0, len(jobs))
+
+	for _, job := range jobs {
+		status := JobStatus{
+			Name:    job.GetName(),
+			NextRun: job.NextRun(),
+			LastRun: job.LastRun(),
+			Running: job.IsRunning(),
+		}
+
+		if tags := job.Tags(); len(tags) > 0 {
+			status.Task = tags[0]
+			status.Result, _ = r.LastResult(status.Task)
+		}
+
+		result = append(result, status)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].NextRun.Before(result[j].NextRun)
+	})
+
+	return result
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/vinicius73/gear-feed/pkg/model"
+	"github.com/vinicius73/gear-feed/pkg/sender"
 	"github.com/vinicius73/gear-feed/pkg/tasks"
 )
 
@@ -11,6 +12,10 @@ type TaskAction string
 
 const (
 	TaskSendLastEntries TaskAction = "send_last_entries"
+	TaskSendLastStories TaskAction = "send_last_stories"
+	TaskBackup          TaskAction = "backup"
+	TaskCleanup         TaskAction = "cleanup"
+	TaskDispatchQueue   TaskAction = "dispatch_queue"
 )
 
 var (
@@ -18,18 +23,22 @@ var (
 	_ tasks.Task[model.IEntry] = (*Task[model.IEntry, tasks.SendLastEntries[model.IEntry]])(nil)
 	_ tasks.Task[model.IEntry] = (*Task[model.IEntry, tasks.Backup[model.IEntry]])(nil)
 	_ tasks.Task[model.IEntry] = (*Task[model.IEntry, tasks.Cleanup[model.IEntry]])(nil)
+	_ tasks.Task[model.IEntry] = (*Task[model.IEntry, tasks.DispatchQueue[model.IEntry]])(nil)
 )
 
 type ScheduleTask[A model.IEntry] interface {
 	tasks.Task[A]
 	GetSchedules() []string
 	Chats() []int64
+	Targets() []sender.Destination
 }
 
 type Task[A model.IEntry, T tasks.Task[A]] struct {
 	Config    T        `fig:"config"    yaml:"config"`
 	Schedules []string `fig:"schedules" yaml:"schedules"`
 	ChatIDs   []int64  `fig:"chats"     yaml:"chats"`
+	// Destinations fan-out the task deliveries, chats are used when empty.
+	Destinations []sender.Destination `fig:"destinations" yaml:"destinations,omitempty"`
 }
 
 func (t Task[A, T]) Name() string {
@@ -47,3 +56,21 @@ func (t Task[A, T]) GetSchedules() []string {
 func (t Task[A, T]) Chats() []int64 {
 	return t.ChatIDs
 }
+
+// Targets returns the destinations, chats are included as a telegram destination.
+func (t Task[A, T]) Targets() []sender.Destination {
+	if len(t.Destinations) == 0 {
+		return nil
+	}
+
+	targets := make([]sender.Destination, 0, len(t.Destinations)+1)
+
+	if len(t.ChatIDs) > 0 {
+		targets = append(targets, sender.Destination{
+			Type:  sender.DestinationTelegram,
+			Chats: t.ChatIDs,
+		})
+	}
+
+	return append(targets, t.Destinations...)
+}
@@ -0,0 +1,277 @@
+package discord
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/vinicius73/gear-feed/pkg/support/apperrors"
+)
+
+const (
+	requestTimeout = time.Second * 60
+	maxRetries     = 3
+	// Discord limits.
+	ContentLimit    = 2000
+	EmbedTitleLimit = 256
+)
+
+var (
+	ErrRequestFailed = apperrors.System(nil, "discord request failed", "DISCORD:REQUEST_FAILED")
+	ErrRateLimited   = apperrors.Business("discord rate limit exceeded", "DISCORD:RATE_LIMITED")
+)
+
+type Config struct {
+	Webhooks  []string `fig:"webhooks"   yaml:"webhooks"`
+	Username  string   `fig:"username"   yaml:"username"`
+	AvatarURL string   `fig:"avatar_url" yaml:"avatar_url"`
+}
+
+func (c Config) Enabled() bool {
+	return len(c.Webhooks) > 0
+}
+
+type Message struct {
+	Content   string  `json:"content,omitempty"`
+	Username  string  `json:"username,omitempty"`
+	AvatarURL string  `json:"avatar_url,omitempty"`
+	Embeds    []Embed `json:"embeds,omitempty"`
+}
+
+type Embed struct {
+	Title       string       `json:"title,omitempty"`
+	URL         string       `json:"url,omitempty"`
+	Description string       `json:"description,omitempty"`
+	Timestamp   string       `json:"timestamp,omitempty"`
+	Image       *EmbedImage  `json:"image,omitempty"`
+	Footer      *EmbedFooter `json:"footer,omitempty"`
+}
+
+type EmbedImage struct {
+	URL string `json:"url"`
+}
+
+type EmbedFooter struct {
+	Text string `json:"text"`
+}
+
+// Client executes webhooks, waiting when Discord asks to slow down.
+type Client struct {
+	http      *http.Client
+	username  string
+	avatarURL string
+	limits    *limits
+}
+
+type limits struct {
+	mu    sync.Mutex
+	until map[string]time.Time
+}
+
+func NewClient(cfg Config) Client {
+	return Client{
+		//nolint:exhaustruct
+		http:      &http.Client{Timeout: requestTimeout},
+		username:  cfg.Username,
+		avatarURL: cfg.AvatarURL,
+		limits:    &limits{until: map[string]time.Time{}},
+	}
+}
+
+// Execute posts the message to the webhook, files are sent as attachments.
+// It returns the ID of the created message.
+func (c Client) Execute(ctx context.Context, webhook string, msg Message, files ...string) (string, error) {
+	if msg.Username == "" {
+		msg.Username = c.username
+	}
+
+	if msg.AvatarURL == "" {
+		msg.AvatarURL = c.avatarURL
+	}
+
+	logger := zerolog.Ctx(ctx)
+
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		if err := c.limits.wait(ctx, webhook); err != nil {
+			return "", err
+		}
+
+		id, retry, err := c.do(ctx, webhook, msg, files)
+		if err != nil {
+			return "", err
+		}
+
+		if retry == 0 {
+			return id, nil
+		}
+
+		logger.Warn().Dur("retry_after", retry).Msgf("Discord rate limited, retrying (%d/%d)", attempt+1, maxRetries)
+		c.limits.block(webhook, retry)
+	}
+
+	return "", ErrRateLimited
+}
+
+func (c Client) do(ctx context.Context, webhook string, msg Message, files []string) (string, time.Duration, error) {
+	body, contentType, err := buildBody(msg, files)
+	if err != nil {
+		return "", 0, ErrRequestFailed.Wrap(err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook+"?wait=true", body)
+	if err != nil {
+		return "", 0, ErrRequestFailed.Wrap(err)
+	}
+
+	req.Header.Set("Content-Type", contentType)
+
+	res, err := c.http.Do(req)
+	if err != nil {
+		return "", 0, ErrRequestFailed.Wrap(err)
+	}
+
+	defer res.Body.Close()
+
+	content, _ := io.ReadAll(res.Body)
+
+	if res.StatusCode == http.StatusTooManyRequests {
+		return "", retryAfter(res.Header, content), nil
+	}
+
+	if res.Header.Get("X-RateLimit-Remaining") == "0" {
+		c.limits.block(webhook, parseSeconds(res.Header.Get("X-RateLimit-Reset-After")))
+	}
+
+	if res.StatusCode >= http.StatusBadRequest {
+		return "", 0, ErrRequestFailed.Wrap(fmt.Errorf("status %d: %s", res.StatusCode, content)) //nolint:err113
+	}
+
+	var created struct {
+		ID string `json:"id"`
+	}
+
+	// without content (204), there is no message ID
+	_ = json.Unmarshal(content, &created)
+
+	return created.ID, 0, nil
+}
+
+func buildBody(msg Message, files []string) (io.Reader, string, error) {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if len(files) == 0 {
+		return bytes.NewReader(payload), "application/json", nil
+	}
+
+	var buf bytes.Buffer
+
+	writer := multipart.NewWriter(&buf)
+
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", `form-data; name="payload_json"`)
+	header.Set("Content-Type", "application/json")
+
+	part, err := writer.CreatePart(header)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if _, err = part.Write(payload); err != nil {
+		return nil, "", err
+	}
+
+	for index, file := range files {
+		if err = attachFile(writer, index, file); err != nil {
+			return nil, "", err
+		}
+	}
+
+	if err = writer.Close(); err != nil {
+		return nil, "", err
+	}
+
+	return &buf, writer.FormDataContentType(), nil
+}
+
+func attachFile(writer *multipart.Writer, index int, file string) error {
+	opened, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+
+	defer opened.Close()
+
+	part, err := writer.CreateFormFile("files["+strconv.Itoa(index)+"]", filepath.Base(file))
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(part, opened)
+
+	return err
+}
+
+func retryAfter(header http.Header, body []byte) time.Duration {
+	var payload struct {
+		RetryAfter float64 `json:"retry_after"`
+	}
+
+	if err := json.Unmarshal(body, &payload); err == nil && payload.RetryAfter > 0 {
+		return time.Duration(payload.RetryAfter * float64(time.Second))
+	}
+
+	if wait := parseSeconds(header.Get("Retry-After")); wait > 0 {
+		return wait
+	}
+
+	return time.Second
+}
+
+func parseSeconds(val string) time.Duration {
+	seconds, err := strconv.ParseFloat(val, 64)
+	if err != nil {
+		return 0
+	}
+
+	return time.Duration(seconds * float64(time.Second))
+}
+
+func (l *limits) block(webhook string, wait time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.until[webhook] = time.Now().Add(wait)
+}
+
+func (l *limits) wait(ctx context.Context, webhook string) error {
+	l.mu.Lock()
+	until := l.until[webhook]
+	l.mu.Unlock()
+
+	wait := time.Until(until)
+
+	if wait <= 0 {
+		return nil
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(wait):
+		return nil
+	}
+}
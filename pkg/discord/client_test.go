@@ -0,0 +1,128 @@
+package discord_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vinicius73/gear-feed/pkg/discord"
+)
+
+func TestExecuteJSON(t *testing.T) {
+	t.Parallel()
+
+	var received discord.Message
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "true", r.URL.Query().Get("wait"))
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"id": "1234"}`))
+	}))
+
+	defer server.Close()
+
+	client := discord.NewClient(discord.Config{Username: "gfeed"})
+
+	id, err := client.Execute(context.TODO(), server.URL, discord.Message{
+		Embeds: []discord.Embed{{Title: "Hello", URL: "https://foo.bar"}},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1234", id)
+	assert.Equal(t, "gfeed", received.Username)
+	assert.Equal(t, "Hello", received.Embeds[0].Title)
+}
+
+func TestExecuteRateLimited(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"message": "You are being rate limited.", "retry_after": 0.2, "global": false}`))
+
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	defer server.Close()
+
+	startedAt := time.Now()
+
+	_, err := discord.NewClient(discord.Config{}).Execute(context.TODO(), server.URL, discord.Message{Content: "hi"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), calls.Load())
+	assert.GreaterOrEqual(t, time.Since(startedAt), time.Millisecond*200)
+}
+
+func TestExecuteFailure(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+
+	defer server.Close()
+
+	_, err := discord.NewClient(discord.Config{}).Execute(context.TODO(), server.URL, discord.Message{Content: "hi"})
+
+	assert.ErrorContains(t, err, discord.ErrRequestFailed.ErrorCode)
+}
+
+func TestExecuteWithFiles(t *testing.T) {
+	t.Parallel()
+
+	file := filepath.Join(t.TempDir(), "video.mp4")
+
+	assert.NoError(t, os.WriteFile(file, []byte("video-data"), 0o600))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, r.ParseMultipartForm(1<<20))
+
+		var payload discord.Message
+
+		assert.NoError(t, json.Unmarshal([]byte(r.FormValue("payload_json")), &payload))
+		assert.Equal(t, "caption", payload.Content)
+
+		upload, header, err := r.FormFile("files[0]")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "video.mp4", header.Filename)
+
+		content, _ := io.ReadAll(upload)
+
+		assert.Equal(t, "video-data", string(content))
+
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	defer server.Close()
+
+	_, err := discord.NewClient(discord.Config{}).Execute(context.TODO(), server.URL, discord.Message{Content: "caption"}, file)
+
+	assert.NoError(t, err)
+}
+
+func TestFromHTML(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t,
+		"ℹ️ **gfeed - `host`**\n🤖 *dev*\n[Link](https://foo.bar?a=1&b=2) 1 < 2",
+		discord.FromHTML("ℹ️ <b>gfeed - <code>host</code></b>\n🤖 <i>dev</i>\n<a href=\"https://foo.bar?a=1&amp;b=2\">Link</a> 1 &lt; 2"),
+	)
+}
@@ -0,0 +1,37 @@
+package discord
+
+import (
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/vinicius73/gear-feed/pkg/support"
+)
+
+var (
+	reLink = regexp.MustCompile(`<a href="([^"]*)">(.*?)</a>`)
+	reTags = regexp.MustCompile(`<[^>]+>`)
+)
+
+var htmlToMarkdown = strings.NewReplacer(
+	"<b>", "**", "</b>", "**",
+	"<strong>", "**", "</strong>", "**",
+	"<i>", "*", "</i>", "*",
+	"<em>", "*", "</em>", "*",
+	"<code>", "`", "</code>", "`",
+	"<pre>", "```\n", "</pre>", "\n```",
+)
+
+// FromHTML converts the telegram HTML used by the messages into Discord markdown.
+func FromHTML(input string) string {
+	output := reLink.ReplaceAllString(input, "[$2]($1)")
+	output = htmlToMarkdown.Replace(output)
+	output = reTags.ReplaceAllString(output, "")
+
+	return html.UnescapeString(output)
+}
+
+// Truncate text to limit runes.
+func Truncate(text string, limit int) string {
+	return support.Truncate(text, limit)
+}
@@ -0,0 +1,167 @@
+package feed
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gosimple/slug"
+	"github.com/vinicius73/gear-feed/pkg"
+	"github.com/vinicius73/gear-feed/pkg/model"
+	"github.com/vinicius73/gear-feed/pkg/sources"
+	"github.com/vinicius73/gear-feed/pkg/storage"
+	"github.com/vinicius73/gear-feed/pkg/support/apperrors"
+)
+
+var ErrScopeNotFound = apperrors.NotFound("feed", "FEED:NOT_FOUND")
+
+type ScopeKind string
+
+const (
+	ScopeGlobal ScopeKind = "global"
+	ScopeSource ScopeKind = "sources"
+	ScopeDir    ScopeKind = "dirs"
+)
+
+const globalName = "feed"
+
+// Scope defines which entries are included in a feed.
+type Scope struct {
+	Kind  ScopeKind
+	Name  string
+	Title string
+	// Sources filters the entries, the global scope includes all of them.
+	Sources []string
+}
+
+// Scopes returns the global scope, one scope for each source directory and one for each source.
+func Scopes(ctx context.Context, opts sources.LoadOptions) ([]Scope, error) {
+	scopes := []Scope{{Kind: ScopeGlobal, Name: globalName, Title: "", Sources: nil}}
+	bySource := []Scope{}
+
+	for _, path := range opts.Paths {
+		list, err := sources.Load(ctx, sources.LoadOptions{Paths: []string{path}, Only: opts.Only})
+		if err != nil {
+			return scopes, err
+		}
+
+		dir := filepath.Base(filepath.Clean(path))
+
+		scopes = append(scopes, Scope{
+			Kind:    ScopeDir,
+			Name:    slug.Make(dir),
+			Title:   dir,
+			Sources: list.Names(),
+		})
+
+		for _, name := range list.Names() {
+			bySource = append(bySource, Scope{
+				Kind:    ScopeSource,
+				Name:    slug.Make(name),
+				Title:   name,
+				Sources: []string{name},
+			})
+		}
+	}
+
+	return append(scopes, bySource...), nil
+}
+
+// FindScope by its kind and name.
+func FindScope(scopes []Scope, kind ScopeKind, name string) (Scope, error) {
+	for _, scope := range scopes {
+		if scope.Kind == kind && scope.Name == name {
+			return scope, nil
+		}
+	}
+
+	return Scope{}, ErrScopeNotFound
+}
+
+// Path of the feed file, relative to the feeds root.
+func (s Scope) Path(format Format) string {
+	if s.Kind == ScopeGlobal {
+		return format.Filename(s.Name)
+	}
+
+	return string(s.Kind) + "/" + format.Filename(s.Name)
+}
+
+// ParseFilename splits a feed file name into the scope name and format.
+func ParseFilename(file string) (string, Format, error) {
+	for _, format := range Formats {
+		suffix := format.Filename("")
+
+		if name, found := strings.CutSuffix(file, suffix); found && name != "" {
+			return name, format, nil
+		}
+	}
+
+	return "", "", ErrUnknownFormat.Msgf(file)
+}
+
+// Build the feed with the last sent entries of the scope.
+func Build[T model.IEntry](store storage.Storage[T], cfg Config, scope Scope, format Format) (Feed, error) {
+	limit := cfg.Limit
+
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+
+	feed := Feed{
+		ID:          "urn:gfeed:" + scope.Path(""),
+		Title:       cfg.Title,
+		Description: cfg.Description,
+		Link:        cfg.Link,
+		SelfLink:    "",
+		Updated:     time.Now(),
+		Items:       []Item{},
+	}
+
+	if feed.Title == "" {
+		feed.Title = pkg.AppName
+	}
+
+	if scope.Title != "" {
+		feed.Title += " - " + scope.Title
+	}
+
+	if cfg.Link != "" {
+		feed.SelfLink = strings.TrimSuffix(cfg.Link, "/") + "/" + scope.Path(format)
+	}
+
+	// a directory without sources has no entries
+	if scope.Kind != ScopeGlobal && len(scope.Sources) == 0 {
+		return feed, nil
+	}
+
+	entries, err := store.FindSent(storage.FindSentOptions{
+		SourceNames: scope.Sources,
+		Limit:       limit,
+	})
+	if err != nil {
+		return feed, err
+	}
+
+	for index, entry := range entries {
+		if index == 0 {
+			feed.Updated = entry.CreatedAt
+		}
+
+		feed.Items = append(feed.Items, Item{
+			ID:        model.CanonicalURL(entry.Data.Link()),
+			Title:     entry.Data.Text(),
+			Link:      entry.Data.Link(),
+			Image:     entry.Data.ImageURL(),
+			Source:    entry.Data.Source(),
+			Tags:      entry.Data.Tags(),
+			Summary:   entry.Data.Summary(),
+			Site:      entry.Data.Site(),
+			Published: entry.Data.Published(),
+			Sent:      entry.CreatedAt,
+		})
+	}
+
+	return feed, nil
+}
@@ -0,0 +1,78 @@
+package feed
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/rs/zerolog"
+	"github.com/vinicius73/gear-feed/pkg/model"
+	"github.com/vinicius73/gear-feed/pkg/storage"
+	"github.com/vinicius73/gear-feed/pkg/support"
+)
+
+type ExportOptions[T model.IEntry] struct {
+	Config  Config
+	Storage storage.Storage[T]
+	Output  string
+	Formats []Format
+	// Kinds limits the exported scopes, all of them are exported when empty.
+	Kinds []ScopeKind
+}
+
+// Export writes the feeds into the output directory, returning the written files.
+func Export[T model.IEntry](ctx context.Context, opts ExportOptions[T]) ([]string, error) {
+	logger := zerolog.Ctx(ctx)
+	files := []string{}
+
+	scopes, err := Scopes(ctx, opts.Config.Sources)
+	if err != nil {
+		return files, err
+	}
+
+	formats := opts.Formats
+
+	if len(formats) == 0 {
+		formats = Formats
+	}
+
+	for _, scope := range scopes {
+		if len(opts.Kinds) > 0 && !support.Contains(opts.Kinds, scope.Kind) {
+			continue
+		}
+
+		for _, format := range formats {
+			file := filepath.Join(opts.Output, filepath.FromSlash(scope.Path(format)))
+
+			if err = write(opts, scope, format, file); err != nil {
+				return files, err
+			}
+
+			logger.Debug().Str("file", file).Msg("Feed exported")
+
+			files = append(files, file)
+		}
+	}
+
+	return files, nil
+}
+
+func write[T model.IEntry](opts ExportOptions[T], scope Scope, format Format, file string) error {
+	feed, err := Build(opts.Storage, opts.Config, scope, format)
+	if err != nil {
+		return err
+	}
+
+	if err = support.DirMustExist(filepath.Dir(file)); err != nil {
+		return err
+	}
+
+	out, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+
+	defer out.Close()
+
+	return feed.Render(out, format)
+}
@@ -0,0 +1,122 @@
+package feed
+
+import (
+	"io"
+	"strings"
+	"time"
+
+	"github.com/vinicius73/gear-feed/pkg/sources"
+	"github.com/vinicius73/gear-feed/pkg/support/apperrors"
+)
+
+const defaultLimit = 50
+
+var ErrUnknownFormat = apperrors.Business("unknown feed format: %s", "FEED:UNKNOWN_FORMAT")
+
+type Format string
+
+const (
+	RSS  Format = "rss"
+	Atom Format = "atom"
+	JSON Format = "json"
+)
+
+var Formats = []Format{RSS, Atom, JSON}
+
+type Config struct {
+	Title       string `fig:"title"       yaml:"title"`
+	Description string `fig:"description" yaml:"description"`
+	// Link is the public URL where the feeds are served, used to build the self links.
+	Link  string `fig:"link"  yaml:"link"`
+	Limit int    `fig:"limit" yaml:"limit"`
+	// Sources defines the source directories, send_last_entries sources are used when empty.
+	Sources sources.LoadOptions `fig:"sources" yaml:"sources"`
+}
+
+// Feed is the format agnostic representation of a feed.
+type Feed struct {
+	ID          string
+	Title       string
+	Description string
+	Link        string
+	SelfLink    string
+	Updated     time.Time
+	Items       []Item
+}
+
+type Item struct {
+	// ID is the canonical URL, unlike the hash it does not change when the entries are rehashed.
+	ID     string
+	Title  string
+	Link   string
+	Image  string
+	Source string
+	Tags   []string
+	// Summary and Site are filled when the entry is enriched.
+	Summary   string
+	Site      string
+	Published time.Time
+	// Sent is when the entry was delivered by the bot.
+	Sent time.Time
+}
+
+func ParseFormat(val string) (Format, error) {
+	format := Format(strings.ToLower(val))
+
+	for _, known := range Formats {
+		if known == format {
+			return format, nil
+		}
+	}
+
+	return "", ErrUnknownFormat.Msgf(val)
+}
+
+func (f Format) ContentType() string {
+	switch f {
+	case RSS:
+		return "application/rss+xml; charset=utf-8"
+	case Atom:
+		return "application/atom+xml; charset=utf-8"
+	case JSON:
+		return "application/feed+json; charset=utf-8"
+	default:
+		return "text/plain; charset=utf-8"
+	}
+}
+
+// Filename returns the file name used to export the feed.
+func (f Format) Filename(name string) string {
+	switch f {
+	case RSS:
+		return name + ".rss.xml"
+	case Atom:
+		return name + ".atom.xml"
+	case JSON:
+		return name + ".json"
+	default:
+		return name
+	}
+}
+
+func (f Feed) Render(w io.Writer, format Format) error {
+	switch format {
+	case RSS:
+		return renderRSS(w, f)
+	case Atom:
+		return renderAtom(w, f)
+	case JSON:
+		return renderJSON(w, f)
+	default:
+		return ErrUnknownFormat.Msgf(format)
+	}
+}
+
+// Date of the item, the publication date is preferred.
+func (i Item) Date() time.Time {
+	if !i.Published.IsZero() {
+		return i.Published
+	}
+
+	return i.Sent
+}
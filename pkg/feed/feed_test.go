@@ -0,0 +1,264 @@
+package feed_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vinicius73/gear-feed/pkg/feed"
+	"github.com/vinicius73/gear-feed/pkg/model"
+	"github.com/vinicius73/gear-feed/pkg/sources"
+	"github.com/vinicius73/gear-feed/pkg/storage"
+	"github.com/vinicius73/gear-feed/pkg/storage/database"
+)
+
+func setup(t *testing.T) (storage.Storage[model.Entry], feed.Config) {
+	t.Helper()
+
+	dir := filepath.Join(t.TempDir(), "games")
+
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+
+	for _, name := range []string{"FOO", "BAR"} {
+		def := "name: " + name + "\nenabled: true\nurl: https://" + name + ".test\n"
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name+".yml"), []byte(def), 0o600))
+	}
+
+	opts := database.Options{
+		Options: storage.Options{TTL: time.Hour},
+		Path:    filepath.Join(t.TempDir(), "test.sqlite"),
+	}
+
+	db, err := database.Open(context.TODO(), opts)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { db.Close() })
+
+	store, err := database.NewStorage[model.Entry](db, opts)
+	require.NoError(t, err)
+
+	entries := []model.Entry{
+		{Title: "Foo 1", URL: "https://foo.test/1", SourceName: "FOO", Image: "https://foo.test/1.jpg"},
+		{
+			Title:       "Bar 1",
+			URL:         "https://bar.test/1",
+			SourceName:  "BAR",
+			PublishedAt: time.Date(2024, 9, 2, 10, 0, 0, 0, time.UTC),
+			Description: "Bar description",
+			SiteName:    "Bar News",
+		},
+		{Title: "Baz 1", URL: "https://baz.test/1", SourceName: "BAZ"},
+	}
+
+	for _, entry := range entries {
+		require.NoError(t, store.Store(storage.Entry[model.Entry]{Data: entry, Status: storage.StatusSent}))
+	}
+
+	require.NoError(t, store.Store(storage.Entry[model.Entry]{
+		Data:   model.Entry{Title: "Not sent", URL: "https://foo.test/2", SourceName: "FOO"},
+		Status: storage.StatusNew,
+	}))
+
+	return store, feed.Config{
+		Title:   "gfeed",
+		Link:    "https://example.com/feeds/",
+		Sources: sources.LoadOptions{Paths: []string{dir}},
+	}
+}
+
+func TestScopes(t *testing.T) {
+	t.Parallel()
+
+	_, cfg := setup(t)
+
+	scopes, err := feed.Scopes(context.TODO(), cfg.Sources)
+	require.NoError(t, err)
+
+	paths := []string{}
+
+	for _, scope := range scopes {
+		paths = append(paths, scope.Path(feed.RSS))
+	}
+
+	assert.ElementsMatch(t, []string{
+		"feed.rss.xml",
+		"dirs/games.rss.xml",
+		"sources/foo.rss.xml",
+		"sources/bar.rss.xml",
+	}, paths)
+}
+
+func TestBuildAndRender(t *testing.T) {
+	t.Parallel()
+
+	store, cfg := setup(t)
+
+	scopes, err := feed.Scopes(context.TODO(), cfg.Sources)
+	require.NoError(t, err)
+
+	global, err := feed.FindScope(scopes, feed.ScopeGlobal, "feed")
+	require.NoError(t, err)
+
+	built, err := feed.Build(store, cfg, global, feed.Atom)
+	require.NoError(t, err)
+
+	assert.Equal(t, "https://example.com/feeds/feed.atom.xml", built.SelfLink)
+	assert.Len(t, built.Items, 3)
+
+	dir, err := feed.FindScope(scopes, feed.ScopeDir, "games")
+	require.NoError(t, err)
+
+	built, err = feed.Build(store, cfg, dir, feed.RSS)
+	require.NoError(t, err)
+
+	assert.Equal(t, "gfeed - games", built.Title)
+	assert.Len(t, built.Items, 2)
+
+	var rss bytes.Buffer
+
+	require.NoError(t, built.Render(&rss, feed.RSS))
+
+	var doc struct {
+		Channel struct {
+			Title string `xml:"title"`
+			Items []struct {
+				Title     string `xml:"title"`
+				Link      string `xml:"link"`
+				GUID      string `xml:"guid"`
+				PubDate   string `xml:"pubDate"`
+				Enclosure struct {
+					URL string `xml:"url,attr"`
+				} `xml:"enclosure"`
+			} `xml:"item"`
+		} `xml:"channel"`
+	}
+
+	require.NoError(t, xml.Unmarshal(rss.Bytes(), &doc))
+
+	assert.Equal(t, "gfeed - games", doc.Channel.Title)
+	require.Len(t, doc.Channel.Items, 2)
+
+	for _, item := range doc.Channel.Items {
+		assert.Equal(t, item.Link, item.GUID)
+		assert.NotEmpty(t, item.PubDate)
+
+		switch item.Title {
+		case "Foo 1":
+			assert.Equal(t, "https://foo.test/1.jpg", item.Enclosure.URL)
+		case "Bar 1":
+			assert.Equal(t, "Mon, 02 Sep 2024 10:00:00 +0000", item.PubDate)
+		default:
+			t.Errorf("unexpected item %s", item.Title)
+		}
+	}
+
+	source, err := feed.FindScope(scopes, feed.ScopeSource, "bar")
+	require.NoError(t, err)
+
+	built, err = feed.Build(store, cfg, source, feed.JSON)
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+
+	require.NoError(t, built.Render(&out, feed.JSON))
+
+	var jsonDoc map[string]any
+
+	require.NoError(t, json.Unmarshal(out.Bytes(), &jsonDoc))
+
+	assert.Equal(t, "https://jsonfeed.org/version/1.1", jsonDoc["version"])
+	assert.Equal(t, "https://example.com/feeds/sources/bar.json", jsonDoc["feed_url"])
+	require.Len(t, jsonDoc["items"], 1)
+
+	item, _ := jsonDoc["items"].([]any)[0].(map[string]any)
+
+	assert.Equal(t, "https://bar.test/1", item["id"])
+	assert.Equal(t, "Bar description", item["content_text"])
+	assert.Equal(t, []any{map[string]any{"name": "Bar News"}}, item["authors"])
+}
+
+func TestMount(t *testing.T) {
+	t.Parallel()
+
+	store, cfg := setup(t)
+
+	mux := http.NewServeMux()
+
+	feed.Mount(mux, "/feeds", store, cfg)
+
+	server := httptest.NewServer(mux)
+
+	defer server.Close()
+
+	tests := map[string]int{
+		"/feeds/feed.atom.xml":       http.StatusOK,
+		"/feeds/dirs/games.json":     http.StatusOK,
+		"/feeds/sources/foo.rss.xml": http.StatusOK,
+		"/feeds/sources/baz.rss.xml": http.StatusNotFound,
+		"/feeds/feed.txt":            http.StatusNotFound,
+	}
+
+	for path, status := range tests {
+		res, err := http.Get(server.URL + path) //nolint:noctx
+		require.NoError(t, err)
+
+		res.Body.Close()
+
+		assert.Equal(t, status, res.StatusCode, path)
+	}
+
+	res, err := http.Get(server.URL + "/feeds/feed.atom.xml") //nolint:noctx
+	require.NoError(t, err)
+
+	defer res.Body.Close()
+
+	assert.Equal(t, feed.Atom.ContentType(), res.Header.Get("Content-Type"))
+}
+
+func TestExport(t *testing.T) {
+	t.Parallel()
+
+	store, cfg := setup(t)
+	output := t.TempDir()
+
+	files, err := feed.Export(context.TODO(), feed.ExportOptions[model.Entry]{
+		Config:  cfg,
+		Storage: store,
+		Output:  output,
+		Formats: []feed.Format{feed.RSS, feed.JSON},
+		Kinds:   []feed.ScopeKind{feed.ScopeGlobal, feed.ScopeDir},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		filepath.Join(output, "feed.rss.xml"),
+		filepath.Join(output, "feed.json"),
+		filepath.Join(output, "dirs", "games.rss.xml"),
+		filepath.Join(output, "dirs", "games.json"),
+	}, files)
+
+	for _, file := range files {
+		assert.FileExists(t, file)
+	}
+}
+
+func TestParseFilename(t *testing.T) {
+	t.Parallel()
+
+	name, format, err := feed.ParseFilename("foo.atom.xml")
+	require.NoError(t, err)
+	assert.Equal(t, "foo", name)
+	assert.Equal(t, feed.Atom, format)
+
+	_, _, err = feed.ParseFilename(".json")
+	assert.Error(t, err)
+}
@@ -0,0 +1,84 @@
+package feed
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+
+	"github.com/rs/zerolog"
+	"github.com/vinicius73/gear-feed/pkg/model"
+	"github.com/vinicius73/gear-feed/pkg/storage"
+	"github.com/vinicius73/gear-feed/pkg/support/apperrors"
+)
+
+// Mount registers the feed routes, using the same paths of the exported files.
+//
+//	{prefix}/feed.rss.xml
+//	{prefix}/sources/{file}
+//	{prefix}/dirs/{file}
+func Mount[T model.IEntry](mux *http.ServeMux, prefix string, store storage.Storage[T], cfg Config) {
+	handler := func(kind ScopeKind) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			serve(w, r, store, cfg, kind)
+		}
+	}
+
+	mux.HandleFunc("GET "+prefix+"/{file}", handler(ScopeGlobal))
+	mux.HandleFunc("GET "+prefix+"/sources/{file}", handler(ScopeSource))
+	mux.HandleFunc("GET "+prefix+"/dirs/{file}", handler(ScopeDir))
+}
+
+func serve[T model.IEntry](w http.ResponseWriter, r *http.Request, store storage.Storage[T], cfg Config, kind ScopeKind) {
+	logger := zerolog.Ctx(r.Context())
+
+	name, format, err := ParseFilename(r.PathValue("file"))
+	if err != nil {
+		http.NotFound(w, r)
+
+		return
+	}
+
+	scopes, err := Scopes(r.Context(), cfg.Sources)
+	if err != nil {
+		logger.Error().Err(err).Msg("Fail to load feed scopes")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	scope, err := FindScope(scopes, kind, name)
+	if err != nil {
+		var notFound apperrors.NotFoundError
+
+		if errors.As(err, &notFound) {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	feed, err := Build(store, cfg, scope, format)
+	if err != nil {
+		logger.Error().Err(err).Str("feed", scope.Path(format)).Msg("Fail to build feed")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	var buf bytes.Buffer
+
+	if err = feed.Render(&buf, format); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Header().Set("Last-Modified", feed.Updated.UTC().Format(http.TimeFormat))
+
+	_, _ = w.Write(buf.Bytes())
+}
@@ -0,0 +1,253 @@
+package feed
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"time"
+)
+
+const jsonFeedVersion = "https://jsonfeed.org/version/1.1"
+
+type rssDocument struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	AtomNS  string     `xml:"xmlns:atom,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	AtomLink      *atomLink `xml:"atom:link,omitempty"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string        `xml:"title"`
+	Link        string        `xml:"link"`
+	Description string        `xml:"description,omitempty"`
+	GUID        rssGUID       `xml:"guid"`
+	PubDate     string        `xml:"pubDate,omitempty"`
+	Categories  []string      `xml:"category"`
+	Enclosure   *rssEnclosure `xml:"enclosure,omitempty"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type rssEnclosure struct {
+	URL    string `xml:"url,attr"`
+	Type   string `xml:"type,attr"`
+	Length int    `xml:"length,attr"`
+}
+
+type atomDocument struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Links   []atomLink  `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomEntry struct {
+	ID         string         `xml:"id"`
+	Title      string         `xml:"title"`
+	Summary    string         `xml:"summary,omitempty"`
+	Author     *atomAuthor    `xml:"author,omitempty"`
+	Updated    string         `xml:"updated"`
+	Published  string         `xml:"published,omitempty"`
+	Links      []atomLink     `xml:"link"`
+	Categories []atomCategory `xml:"category"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type jsonFeed struct {
+	Version     string     `json:"version"`
+	Title       string     `json:"title"`
+	HomePageURL string     `json:"home_page_url,omitempty"`
+	FeedURL     string     `json:"feed_url,omitempty"`
+	Description string     `json:"description,omitempty"`
+	Items       []jsonItem `json:"items"`
+}
+
+type jsonItem struct {
+	ID            string       `json:"id"`
+	URL           string       `json:"url"`
+	Title         string       `json:"title"`
+	ContentText   string       `json:"content_text"`
+	Summary       string       `json:"summary,omitempty"`
+	Image         string       `json:"image,omitempty"`
+	DatePublished string       `json:"date_published,omitempty"`
+	DateModified  string       `json:"date_modified,omitempty"`
+	Tags          []string     `json:"tags,omitempty"`
+	Authors       []jsonAuthor `json:"authors,omitempty"`
+}
+
+type jsonAuthor struct {
+	Name string `json:"name"`
+}
+
+func renderRSS(w io.Writer, feed Feed) error {
+	doc := rssDocument{
+		Version: "2.0",
+		AtomNS:  "http://www.w3.org/2005/Atom",
+		Channel: rssChannel{
+			Title:         feed.Title,
+			Link:          feed.Link,
+			Description:   feed.Description,
+			LastBuildDate: formatDate(feed.Updated, time.RFC1123Z),
+			Items:         make([]rssItem, len(feed.Items)),
+		},
+	}
+
+	if feed.SelfLink != "" {
+		doc.Channel.AtomLink = &atomLink{Href: feed.SelfLink, Rel: "self", Type: RSS.ContentType()}
+	}
+
+	for index, item := range feed.Items {
+		entry := rssItem{
+			Title:       item.Title,
+			Link:        item.Link,
+			Description: item.Summary,
+			GUID:        rssGUID{IsPermaLink: true, Value: item.ID},
+			PubDate:     formatDate(item.Date(), time.RFC1123Z),
+			Categories:  item.Tags,
+		}
+
+		if item.Image != "" {
+			entry.Enclosure = &rssEnclosure{URL: item.Image, Type: "image/jpeg", Length: 0}
+		}
+
+		doc.Channel.Items[index] = entry
+	}
+
+	return writeXML(w, doc)
+}
+
+func renderAtom(w io.Writer, feed Feed) error {
+	doc := atomDocument{
+		ID:      feed.ID,
+		Title:   feed.Title,
+		Updated: formatDate(feed.Updated, time.RFC3339),
+		Links:   []atomLink{{Href: feed.Link, Rel: "alternate"}},
+		Entries: make([]atomEntry, len(feed.Items)),
+	}
+
+	if feed.SelfLink != "" {
+		doc.Links = append(doc.Links, atomLink{Href: feed.SelfLink, Rel: "self", Type: Atom.ContentType()})
+	}
+
+	for index, item := range feed.Items {
+		entry := atomEntry{
+			ID:         item.ID,
+			Title:      item.Title,
+			Summary:    item.Summary,
+			Author:     nil,
+			Updated:    formatDate(item.Sent, time.RFC3339),
+			Published:  formatDate(item.Published, time.RFC3339),
+			Links:      []atomLink{{Href: item.Link, Rel: "alternate"}},
+			Categories: make([]atomCategory, len(item.Tags)),
+		}
+
+		if item.Image != "" {
+			entry.Links = append(entry.Links, atomLink{Href: item.Image, Rel: "enclosure", Type: "image/jpeg"})
+		}
+
+		if item.Site != "" {
+			entry.Author = &atomAuthor{Name: item.Site}
+		}
+
+		for i, tag := range item.Tags {
+			entry.Categories[i] = atomCategory{Term: tag}
+		}
+
+		doc.Entries[index] = entry
+	}
+
+	return writeXML(w, doc)
+}
+
+func renderJSON(w io.Writer, feed Feed) error {
+	doc := jsonFeed{
+		Version:     jsonFeedVersion,
+		Title:       feed.Title,
+		HomePageURL: feed.Link,
+		FeedURL:     feed.SelfLink,
+		Description: feed.Description,
+		Items:       make([]jsonItem, len(feed.Items)),
+	}
+
+	for index, item := range feed.Items {
+		entry := jsonItem{
+			ID:            item.ID,
+			URL:           item.Link,
+			Title:         item.Title,
+			ContentText:   item.Title,
+			Summary:       item.Summary,
+			Image:         item.Image,
+			DatePublished: formatDate(item.Date(), time.RFC3339),
+			DateModified:  formatDate(item.Sent, time.RFC3339),
+			Tags:          item.Tags,
+			Authors:       nil,
+		}
+
+		if item.Summary != "" {
+			entry.ContentText = item.Summary
+		}
+
+		if item.Site != "" {
+			entry.Authors = []jsonAuthor{{Name: item.Site}}
+		}
+
+		doc.Items[index] = entry
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(doc)
+}
+
+func writeXML(w io.Writer, doc any) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+
+	if err := encoder.Encode(doc); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+
+	return err
+}
+
+func formatDate(date time.Time, layout string) string {
+	if date.IsZero() {
+		return ""
+	}
+
+	return date.UTC().Format(layout)
+}
@@ -0,0 +1,212 @@
+package filters
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/vinicius73/gear-feed/pkg/model"
+	"github.com/vinicius73/gear-feed/pkg/support"
+	"github.com/vinicius73/gear-feed/pkg/support/apperrors"
+)
+
+const (
+	FieldTitle    = "title"
+	FieldURL      = "url"
+	FieldCategory = "category"
+)
+
+var ErrInvalidRule = apperrors.Business("invalid filter rule: %s", "FILTERS:INVALID_RULE")
+
+var knownFields = []string{FieldTitle, FieldURL, FieldCategory}
+
+// compiled caches the regexes of the rules, the filters are plain values loaded from the config.
+var compiled sync.Map
+
+// Filters drops the entries matching an exclude rule, when there are include rules
+// only the entries matching one of them are kept.
+type Filters struct {
+	Include []Rule `fig:"include" yaml:"include,omitempty"`
+	Exclude []Rule `fig:"exclude" yaml:"exclude,omitempty"`
+}
+
+// Rule matches when one of the words or the regex is found in one of the fields.
+// The comparison ignores case and accents.
+type Rule struct {
+	// Words are matched as whole words, "review de celular" matches only the phrase.
+	Words []string `fig:"words" yaml:"words,omitempty"`
+	Regex string   `fig:"regex" yaml:"regex,omitempty"`
+	// Fields are title, url and category, all of them when empty.
+	Fields []string `fig:"fields" yaml:"fields,omitempty"`
+}
+
+// Fields of an entry checked by the rules.
+type Fields struct {
+	Title      string
+	URL        string
+	Categories []string
+}
+
+func FromEntry(entry model.IEntry) Fields {
+	fields := Fields{Title: entry.Text(), URL: entry.Link(), Categories: []string{}}
+
+	if current, ok := entry.(model.Entry); ok {
+		fields.Categories = current.Categories
+	}
+
+	return fields
+}
+
+func (f Filters) Empty() bool {
+	return len(f.Include) == 0 && len(f.Exclude) == 0
+}
+
+func (f Filters) Validate() error {
+	for _, rule := range append(append([]Rule{}, f.Include...), f.Exclude...) {
+		if err := rule.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (f Filters) Allow(fields Fields) bool {
+	for _, rule := range f.Exclude {
+		if rule.Match(fields) {
+			return false
+		}
+	}
+
+	if len(f.Include) == 0 {
+		return true
+	}
+
+	for _, rule := range f.Include {
+		if rule.Match(fields) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Apply returns the entries allowed by the filters.
+func Apply[T model.IEntry](f Filters, entries []T) []T {
+	if f.Empty() {
+		return entries
+	}
+
+	result := []T{}
+
+	for _, entry := range entries {
+		if f.Allow(FromEntry(entry)) {
+			result = append(result, entry)
+		}
+	}
+
+	return result
+}
+
+func (r Rule) Validate() error {
+	if len(r.Words) == 0 && r.Regex == "" {
+		return ErrInvalidRule.Msgf("words or regex is required")
+	}
+
+	for _, field := range r.Fields {
+		if !support.Contains(knownFields, strings.ToLower(field)) {
+			return ErrInvalidRule.Msgf(fmt.Sprintf("unknown field %q, expected %s", field, strings.Join(knownFields, ", ")))
+		}
+	}
+
+	if r.Regex != "" {
+		if _, err := compile(r.Regex); err != nil {
+			return ErrInvalidRule.Msgf(err.Error())
+		}
+	}
+
+	return nil
+}
+
+func (r Rule) Match(fields Fields) bool {
+	values := []string{}
+
+	if r.uses(FieldTitle) {
+		values = append(values, fields.Title)
+	}
+
+	if r.uses(FieldURL) {
+		values = append(values, fields.URL)
+	}
+
+	if r.uses(FieldCategory) {
+		values = append(values, fields.Categories...)
+	}
+
+	var re *regexp.Regexp
+
+	if r.Regex != "" {
+		// invalid regexes are reported by Validate
+		re, _ = compile(r.Regex)
+	}
+
+	for _, value := range values {
+		if re != nil && re.MatchString(support.RemoveAccents(value)) {
+			return true
+		}
+
+		tokens := tokenize(value)
+
+		for _, word := range r.Words {
+			if containsPhrase(tokens, tokenize(word)) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (r Rule) uses(field string) bool {
+	if len(r.Fields) == 0 {
+		return true
+	}
+
+	_, found := support.FindIndex(r.Fields, func(value string) bool {
+		return strings.EqualFold(value, field)
+	})
+
+	return found
+}
+
+func compile(pattern string) (*regexp.Regexp, error) {
+	if re, found := compiled.Load(pattern); found {
+		return re.(*regexp.Regexp), nil //nolint:forcetypeassert
+	}
+
+	re, err := regexp.Compile("(?i)" + support.RemoveAccents(pattern))
+	if err != nil {
+		return nil, err
+	}
+
+	compiled.Store(pattern, re)
+
+	return re, nil
+}
+
+// tokenize splits the text in words, the punctuation of URLs is a separator too.
+func tokenize(text string) []string {
+	return strings.FieldsFunc(support.Fold(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func containsPhrase(tokens, phrase []string) bool {
+	if len(phrase) == 0 {
+		return false
+	}
+
+	return strings.Contains(" "+strings.Join(tokens, " ")+" ", " "+strings.Join(phrase, " ")+" ")
+}
@@ -0,0 +1,108 @@
+package filters_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vinicius73/gear-feed/pkg/filters"
+	"github.com/vinicius73/gear-feed/pkg/model"
+)
+
+func TestFiltersAllow(t *testing.T) {
+	t.Parallel()
+
+	filter := filters.Filters{
+		Include: []filters.Rule{},
+		Exclude: []filters.Rule{
+			{Words: []string{"review de celular"}, Regex: "", Fields: []string{}},
+			{Words: []string{"conteudo patrocinado"}, Regex: "", Fields: []string{filters.FieldCategory}},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		fields filters.Fields
+		want   bool
+	}{
+		{
+			name:   "phrase in the title",
+			fields: filters.Fields{Title: "Review de Celular: Galaxy S25", URL: "", Categories: []string{}},
+			want:   false,
+		},
+		{
+			name:   "phrase in the url",
+			fields: filters.Fields{Title: "Galaxy S25", URL: "https://foo.com/review-de-celular/galaxy", Categories: []string{}},
+			want:   false,
+		},
+		{
+			name:   "partial phrase",
+			fields: filters.Fields{Title: "Review do Galaxy S25", URL: "", Categories: []string{}},
+			want:   true,
+		},
+		{
+			name:   "accents of the category",
+			fields: filters.Fields{Title: "Galaxy S25", URL: "", Categories: []string{"Conteúdo Patrocinado"}},
+			want:   false,
+		},
+		{
+			name:   "word of the category in the title",
+			fields: filters.Fields{Title: "Conteúdo patrocinado", URL: "", Categories: []string{}},
+			want:   true,
+		},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.want, filter.Allow(test.fields), test.name)
+	}
+}
+
+func TestFiltersInclude(t *testing.T) {
+	t.Parallel()
+
+	filter := filters.Filters{
+		Include: []filters.Rule{
+			{Words: []string{"nintendo"}, Regex: "", Fields: []string{}},
+			{Words: []string{}, Regex: `lancamento d[oa]s?\b`, Fields: []string{filters.FieldTitle}},
+		},
+		Exclude: []filters.Rule{
+			{Words: []string{"rumor"}, Regex: "", Fields: []string{filters.FieldTitle}},
+		},
+	}
+
+	entries := []model.Entry{
+		{Title: "Nintendo Switch 2 ganha trailer", URL: "https://foo.com/1"},
+		{Title: "Novo iPhone anunciado", URL: "https://foo.com/2"},
+		{Title: "Data de LANÇAMENTO do GTA 6", URL: "https://foo.com/3"},
+		{Title: "Rumor: Nintendo prepara Zelda", URL: "https://foo.com/4"},
+		{Title: "Mario Kart World", URL: "https://foo.com/nintendo/5"},
+	}
+
+	titles := []string{}
+
+	for _, entry := range filters.Apply(filter, entries) {
+		titles = append(titles, entry.Title)
+	}
+
+	assert.Equal(t, []string{"Nintendo Switch 2 ganha trailer", "Data de LANÇAMENTO do GTA 6", "Mario Kart World"}, titles)
+}
+
+func TestFiltersValidate(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, filters.Filters{}.Validate())
+
+	assert.ErrorIs(t, filters.Filters{
+		Include: []filters.Rule{{Words: []string{}, Regex: "", Fields: []string{}}},
+		Exclude: []filters.Rule{},
+	}.Validate(), filters.ErrInvalidRule.Msgf("words or regex is required"))
+
+	assert.ErrorContains(t, filters.Filters{
+		Include: []filters.Rule{},
+		Exclude: []filters.Rule{{Words: []string{"foo"}, Regex: "", Fields: []string{"body"}}},
+	}.Validate(), `unknown field "body"`)
+
+	assert.ErrorContains(t, filters.Filters{
+		Include: []filters.Rule{},
+		Exclude: []filters.Rule{{Words: []string{}, Regex: "(foo", Fields: []string{}}},
+	}.Validate(), "missing closing )")
+}
@@ -0,0 +1,73 @@
+package httpserver
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/vinicius73/gear-feed/pkg/support/apperrors"
+)
+
+const (
+	readHeaderTimeout = time.Second * 10
+	shutdownTimeout   = time.Second * 10
+)
+
+var ErrFailToListen = apperrors.System(nil, "fail to start http server", "HTTP:FAIL_TO_LISTEN")
+
+type Config struct {
+	// Listen is the address of the server, like :8080. The server is disabled when empty.
+	Listen string `fig:"listen" yaml:"listen"`
+}
+
+func (c Config) Enabled() bool {
+	return c.Listen != ""
+}
+
+// Run serves the handler until the context is done.
+func Run(ctx context.Context, cfg Config, handler http.Handler) error {
+	logger := zerolog.Ctx(ctx).With().Str("component", "http").Str("listen", cfg.Listen).Logger()
+
+	server := &http.Server{
+		Addr:              cfg.Listen,
+		Handler:           withLogger(logger, handler),
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+
+	errs := make(chan error, 1)
+
+	go func() {
+		logger.Info().Msg("HTTP server started")
+
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errs <- ErrFailToListen.Wrap(err)
+		}
+
+		close(errs)
+	}()
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+
+	defer cancel()
+
+	logger.Info().Msg("HTTP server stopping")
+
+	//nolint:contextcheck
+	return server.Shutdown(shutdownCtx)
+}
+
+func withLogger(logger zerolog.Logger, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger.Debug().Str("method", r.Method).Str("path", r.URL.Path).Msg("HTTP request")
+
+		next.ServeHTTP(w, r.WithContext(logger.WithContext(r.Context())))
+	})
+}
@@ -3,8 +3,10 @@ package linkloader
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/rs/zerolog"
+	"github.com/vinicius73/gear-feed/pkg/metrics"
 	"github.com/vinicius73/gear-feed/pkg/model"
 	"github.com/vinicius73/gear-feed/pkg/scraper"
 	"github.com/vinicius73/gear-feed/pkg/support"
@@ -13,6 +15,8 @@ import (
 type LoadOptions struct {
 	Workers int
 	Sources []scraper.SourceDefinition
+	// OnResult is optional, it is called by the workers after each source is loaded.
+	OnResult func(source scraper.SourceDefinition, entries int, err error)
 }
 
 func FromSources[T model.IEntry](ctx context.Context, options LoadOptions) (Collections[T], error) {
@@ -28,7 +32,7 @@ func FromSources[T model.IEntry](ctx context.Context, options LoadOptions) (Coll
 
 	for range options.Workers {
 		wg.Add(1)
-		out, errc := loadWorker[T](&wg, ctx, chSources)
+		out, errc := loadWorker[T](&wg, ctx, chSources, options.OnResult)
 
 		chCollections = append(chCollections, out)
 		chErrors = append(chErrors, errc)
@@ -65,18 +69,25 @@ func FromSources[T model.IEntry](ctx context.Context, options LoadOptions) (Coll
 }
 
 func FromSource[T model.IEntry](ctx context.Context, source scraper.SourceDefinition) (Collection[T], error) {
+	startedAt := time.Now()
+
 	entries, err := scraper.FindEntries[T](ctx, source)
+
+	metrics.ScrapeDuration.Observe(metrics.Since(startedAt), source.Name, metrics.Result(err))
+
 	if err != nil {
 		return Collection[T]{}, err
 	}
 
+	metrics.ScrapeEntries.Set(float64(len(entries)), source.Name)
+
 	return Collection[T]{
 		SourceName: source.Name,
 		Entries:    entries,
 	}, nil
 }
 
-func loadWorker[T model.IEntry](wg *sync.WaitGroup, ctx context.Context, input <-chan scraper.SourceDefinition) (<-chan Collection[T], <-chan error) {
+func loadWorker[T model.IEntry](wg *sync.WaitGroup, ctx context.Context, input <-chan scraper.SourceDefinition, onResult func(scraper.SourceDefinition, int, error)) (<-chan Collection[T], <-chan error) {
 	out := make(chan Collection[T], 2)
 	errc := make(chan error, 1)
 
@@ -100,6 +111,9 @@ func loadWorker[T model.IEntry](wg *sync.WaitGroup, ctx context.Context, input <
 					return
 				}
 				collection, err := FromSource[T](ctx, source)
+				if onResult != nil {
+					onResult(source, len(collection.Entries), err)
+				}
 				if err != nil {
 					logger.Error().Err(err).Str("source", source.Name).Msg("Error on load worker")
 					errc <- err
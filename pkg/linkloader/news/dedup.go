@@ -0,0 +1,150 @@
+package news
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/vinicius73/gear-feed/pkg/model"
+	"github.com/vinicius73/gear-feed/pkg/similarity"
+	"github.com/vinicius73/gear-feed/pkg/storage"
+)
+
+const defaultDedupWindow = time.Hour * 48
+
+type DedupConfig struct {
+	// Window is the period a story is compared with the others,
+	// a negative value disables the duplicates detection.
+	Window time.Duration `fig:"window" yaml:"window"`
+	// Threshold is the minimal similarity of the titles, from 0 to 1.
+	Threshold float64 `fig:"threshold" yaml:"threshold"`
+}
+
+func (c DedupConfig) window() time.Duration {
+	if c.Window == 0 {
+		return defaultDedupWindow
+	}
+
+	return c.Window
+}
+
+type candidate[T model.IEntry] struct {
+	result int
+	entry  T
+	item   similarity.Item
+}
+
+// dedupe keeps one entry of each story, the entries similar to the sent or queued ones are removed too.
+func dedupe[T model.IEntry](ctx context.Context, opt LoadOptions[T], results SourceResultEntriesList[T]) (SourceResultEntriesList[T], error) {
+	window := opt.Dedup.window()
+
+	if window < 0 {
+		return results, nil
+	}
+
+	logger := zerolog.Ctx(ctx)
+	clusters := similarity.NewClusters(opt.Dedup.Threshold, window)
+
+	sent, err := opt.Storage.FindRecent(time.Now().Add(-window))
+	if err != nil {
+		return nil, err
+	}
+
+	queued, err := opt.Storage.Queue(storage.FindQueueOptions{Until: time.Time{}, Limit: 0})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range queued {
+		sent = append(sent, item.Data)
+	}
+
+	for _, entry := range sent {
+		item, err := clusterItem(entry)
+		if err != nil {
+			return nil, err
+		}
+
+		clusters.Add(item)
+	}
+
+	candidates := []candidate[T]{}
+
+	for index, result := range results {
+		for _, entry := range result.Entries {
+			item, err := clusterItem(entry)
+			if err != nil {
+				return nil, err
+			}
+
+			candidates = append(candidates, candidate[T]{result: index, entry: entry, item: item})
+		}
+	}
+
+	// the first published entry represents the story, the ties are broken
+	// by source and link, so the same entry is kept whatever the loading order
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+
+		if !a.item.Published.Equal(b.item.Published) {
+			return !a.item.Published.IsZero() && (b.item.Published.IsZero() || a.item.Published.Before(b.item.Published))
+		}
+
+		if a.entry.Source() != b.entry.Source() {
+			return a.entry.Source() < b.entry.Source()
+		}
+
+		return a.item.URL < b.item.URL
+	})
+
+	kept := make([][]T, len(results))
+
+	for _, current := range candidates {
+		if cluster := clusters.Find(current.item); cluster != "" {
+			logger.Debug().
+				Str("source", current.entry.Source()).
+				Str("title", current.entry.Text()).
+				Str("cluster", cluster).
+				Msg("duplicated entry")
+
+			results[current.result].Duplicates++
+
+			continue
+		}
+
+		clusters.Add(current.item)
+
+		//nolint:forcetypeassert
+		kept[current.result] = append(kept[current.result], current.entry.SetCluster(current.item.Cluster).(T))
+	}
+
+	for index := range results {
+		results[index].Entries = append([]T{}, kept[index]...)
+		results[index].Filtered = len(kept[index])
+	}
+
+	return results, nil
+}
+
+// clusterItem uses the entry hash as cluster of the entries without one.
+func clusterItem[T model.IEntry](entry T) (similarity.Item, error) {
+	hash, err := entry.Hash()
+	if err != nil {
+		return similarity.Item{}, err
+	}
+
+	cluster := entry.Cluster()
+
+	if cluster == "" {
+		cluster = hash
+	}
+
+	return similarity.Item{
+		ID:        hash,
+		Cluster:   cluster,
+		Title:     entry.Text(),
+		URL:       entry.Link(),
+		Published: entry.Published(),
+	}, nil
+}
@@ -0,0 +1,232 @@
+package news_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vinicius73/gear-feed/pkg/linkloader"
+	"github.com/vinicius73/gear-feed/pkg/linkloader/news"
+	"github.com/vinicius73/gear-feed/pkg/model"
+	"github.com/vinicius73/gear-feed/pkg/scraper"
+	"github.com/vinicius73/gear-feed/pkg/scraper/testdata"
+	"github.com/vinicius73/gear-feed/pkg/storage"
+	"github.com/vinicius73/gear-feed/pkg/storage/database"
+)
+
+var pages = map[string]string{
+	"/ign": `<html><body>
+		<article><a href="/ign/zelda">Nintendo anuncia novo trailer de Zelda</a></article>
+		<article><a href="/ign/ps6">Sony revela o preço do PS6</a></article>
+	</body></html>`,
+	"/voxel": `<html><body>
+		<article><a href="/voxel/zelda">Zelda ganha novo trailer da Nintendo</a></article>
+		<article><a href="/voxel/re10">Capcom confirma Resident Evil 10</a></article>
+	</body></html>`,
+}
+
+func newStorage(t *testing.T) storage.Storage[model.Entry] {
+	t.Helper()
+
+	opts := database.Options{
+		Options: storage.Options{TTL: time.Hour},
+		Path:    filepath.Join(t.TempDir(), "test.sqlite"),
+	}
+
+	db, err := database.Open(context.TODO(), opts)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { db.Close() })
+
+	store, err := database.NewStorage[model.Entry](db, opts)
+	require.NoError(t, err)
+
+	return store
+}
+
+func newSource(t *testing.T, baseURL, name, path string) scraper.SourceDefinition {
+	t.Helper()
+
+	source, err := testdata.ParseSource(baseURL, `
+name: `+name+`
+paths:
+ - `+path+`
+attributes:
+	entry_selector: "article"
+	link:
+		path: "a"
+		attribute: "href"
+	title:
+		path: "a"
+	`)
+	require.NoError(t, err)
+
+	return source
+}
+
+func TestLoadEntriesDedup(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(pages[r.URL.Path]))
+	}))
+	defer server.Close()
+
+	store := newStorage(t)
+
+	require.NoError(t, store.Store(storage.Entry[model.Entry]{
+		Data: model.Entry{
+			Title:      "Capcom confirma novo Resident Evil 10",
+			URL:        "https://other.com/re10",
+			SourceName: "OTHER",
+			ClusterID:  "resident-evil",
+		},
+		Status: storage.StatusSent,
+	}))
+
+	result, err := news.LoadEntries(scraper.WithoutDelay(context.TODO()), news.LoadOptions[model.Entry]{
+		LoadOptions: linkloader.LoadOptions{
+			Workers: 0,
+			Sources: []scraper.SourceDefinition{
+				newSource(t, server.URL, "IGN", "/ign"),
+				newSource(t, server.URL, "VOXEL", "/voxel"),
+			},
+		},
+		Storage: store,
+		Limit:   10,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 4, result.Loaded)
+	assert.Equal(t, 2, result.Filtered)
+	assert.Equal(t, 2, result.Duplicates)
+	assert.Equal(t, 2, result.Resume().Duplicates)
+
+	titles := []string{}
+
+	for _, entry := range result.Entries {
+		hash, err := entry.Hash()
+		require.NoError(t, err)
+
+		// a new story starts its own cluster
+		assert.Equal(t, hash, entry.Cluster())
+
+		titles = append(titles, entry.Title)
+	}
+
+	sort.Strings(titles)
+
+	// without dates, the entry of the first source is kept
+	assert.Equal(t, []string{"Nintendo anuncia novo trailer de Zelda", "Sony revela o preço do PS6"}, titles)
+
+	// the cluster is stored with the sent entry
+	require.NoError(t, store.Store(storage.Entry[model.Entry]{Data: result.Entries[0], Status: storage.StatusSent}))
+
+	recent, err := store.FindRecent(time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	require.Len(t, recent, 2)
+	assert.ElementsMatch(t, []string{"resident-evil", result.Entries[0].Cluster()}, []string{recent[0].Cluster(), recent[1].Cluster()})
+}
+
+func TestLoadEntriesDedupDisabled(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(pages[r.URL.Path]))
+	}))
+	defer server.Close()
+
+	result, err := news.LoadEntries(scraper.WithoutDelay(context.TODO()), news.LoadOptions[model.Entry]{
+		LoadOptions: linkloader.LoadOptions{
+			Workers: 0,
+			Sources: []scraper.SourceDefinition{
+				newSource(t, server.URL, "IGN", "/ign"),
+				newSource(t, server.URL, "VOXEL", "/voxel"),
+			},
+		},
+		Storage: newStorage(t),
+		Dedup:   news.DedupConfig{Window: -1, Threshold: 0},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 4, result.Filtered)
+	assert.Zero(t, result.Duplicates)
+}
+
+func TestLoadEntriesSkipQueued(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(pages[r.URL.Path]))
+	}))
+	defer server.Close()
+
+	store := newStorage(t)
+
+	_, err := store.Enqueue(storage.QueueItem[model.Entry]{
+		Data:      model.Entry{Title: "Sony revela o preço do PS6", URL: server.URL + "/ign/ps6", SourceName: "IGN"},
+		PlannedAt: time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+
+	result, err := news.LoadEntries(scraper.WithoutDelay(context.TODO()), news.LoadOptions[model.Entry]{
+		LoadOptions: linkloader.LoadOptions{
+			Workers: 0,
+			Sources: []scraper.SourceDefinition{newSource(t, server.URL, "IGN", "/ign")},
+		},
+		Storage:    store,
+		Dedup:      news.DedupConfig{Window: -1, Threshold: 0},
+		SkipQueued: true,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, result.Entries, 1)
+	assert.Contains(t, result.Entries[0].Title, "Zelda")
+}
+
+func TestLoadEntriesDedupQueued(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(pages[r.URL.Path]))
+	}))
+	defer server.Close()
+
+	store := newStorage(t)
+
+	_, err := store.Enqueue(storage.QueueItem[model.Entry]{
+		Data: model.Entry{
+			Title:      "Zelda ganha novo trailer da Nintendo",
+			URL:        "https://other.com/zelda",
+			SourceName: "OTHER",
+			ClusterID:  "zelda",
+		},
+		PlannedAt: time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+
+	result, err := news.LoadEntries(scraper.WithoutDelay(context.TODO()), news.LoadOptions[model.Entry]{
+		LoadOptions: linkloader.LoadOptions{
+			Workers: 0,
+			Sources: []scraper.SourceDefinition{newSource(t, server.URL, "IGN", "/ign")},
+		},
+		Storage:    store,
+		SkipQueued: true,
+	})
+	require.NoError(t, err)
+
+	// the story waiting in the queue is not queued again by another source
+	assert.Equal(t, 1, result.Duplicates)
+	require.Len(t, result.Entries, 1)
+	assert.Equal(t, "Sony revela o preço do PS6", result.Entries[0].Title)
+}
@@ -2,30 +2,56 @@ package news
 
 import (
 	"context"
+	"sort"
+	"sync"
+	"time"
 
 	"github.com/rs/zerolog"
+	"github.com/vinicius73/gear-feed/pkg/filters"
 	"github.com/vinicius73/gear-feed/pkg/linkloader"
 	"github.com/vinicius73/gear-feed/pkg/model"
+	"github.com/vinicius73/gear-feed/pkg/scraper"
 	"github.com/vinicius73/gear-feed/pkg/sender"
+	"github.com/vinicius73/gear-feed/pkg/sources"
 	"github.com/vinicius73/gear-feed/pkg/storage"
-	"github.com/vinicius73/gear-feed/pkg/support"
 )
 
 type LoadOptions[T model.IEntry] struct {
 	LoadOptions linkloader.LoadOptions
 	Storage     storage.Storage[T]
 	Limit       int
+	// MaxPerSource limits the entries of each source, zero means no limit.
+	MaxPerSource int
+	// Seed of the selection, a random seed is used when zero.
+	Seed int64
+	// MaxAge ignores entries published before it, zero means no limit.
+	MaxAge time.Duration
+	// Destinations filters entries already sent to all of them, when empty the entry status is used.
+	Destinations []string
+	// Health quarantines the sources with consecutive failures.
+	Health sources.HealthConfig
+	// Dedup sends only one entry of each story.
+	Dedup DedupConfig
+	// Filters are applied to the entries of all sources.
+	Filters filters.Filters
+	// SkipQueued ignores the entries waiting in the send queue.
+	SkipQueued bool
 }
 
 type SourceResultEntries[T model.IEntry] struct {
 	SourceResult
 	Entries []T
+	// Weight and Priority of the source in the selection.
+	Weight   int
+	Priority int
 }
 
 type SourceResult struct {
 	Source   string
 	Total    int
 	Filtered int
+	// Duplicates are the entries about stories of other entries.
+	Duplicates int
 }
 
 type SourceResultEntriesList[T model.IEntry] []SourceResultEntries[T]
@@ -34,13 +60,50 @@ type Result[T model.IEntry] struct {
 	Results  []SourceResult
 	Loaded   int
 	Filtered int
-	Entries  []T
+	// Duplicates are removed from the filtered entries.
+	Duplicates int
+	Entries    []T
+	// Failed are the sources that could not be loaded.
+	Failed []string
+	// Quarantined are the sources skipped by the health check.
+	Quarantined []storage.SourceHealth
 }
 
 func LoadEntries[T model.IEntry](ctx context.Context, opt LoadOptions[T]) (Result[T], error) {
 	logger := zerolog.Ctx(ctx)
 
-	entries, err := linkloader.LoadEntries[T](ctx, opt.LoadOptions)
+	tracker := sources.NewTracker(opt.Storage, opt.Health)
+
+	available, quarantined, err := tracker.Available(opt.LoadOptions.Sources)
+	if err != nil {
+		return Result[T]{}, err
+	}
+
+	for _, health := range quarantined {
+		logger.Warn().
+			Str("source", health.Source).
+			Time("until", health.QuarantinedUntil).
+			Msg("skipping quarantined source")
+	}
+
+	var mu sync.Mutex
+
+	failed := []string{}
+	loadOptions := opt.LoadOptions
+	loadOptions.Sources = available
+	loadOptions.OnResult = func(source scraper.SourceDefinition, entries int, err error) {
+		if err != nil {
+			mu.Lock()
+			failed = append(failed, source.Name)
+			mu.Unlock()
+		}
+
+		if _, recordErr := tracker.Record(source.Name, entries, err); recordErr != nil {
+			logger.Error().Err(recordErr).Str("source", source.Name).Msg("failed to record source health")
+		}
+	}
+
+	entries, err := linkloader.LoadEntries[T](ctx, loadOptions)
 	if err != nil {
 		return Result[T]{}, err
 	}
@@ -52,6 +115,9 @@ func LoadEntries[T model.IEntry](ctx context.Context, opt LoadOptions[T]) (Resul
 		return Result[T]{}, err
 	}
 
+	results.Failed = failed
+	results.Quarantined = quarantined
+
 	return results, nil
 }
 
@@ -59,24 +125,53 @@ func buildResult[T model.IEntry](ctx context.Context, opt LoadOptions[T], loaded
 	logger := zerolog.Ctx(ctx)
 
 	grouped := map[string][]T{}
+	definitions := map[string]scraper.SourceDefinition{}
+
+	for _, source := range opt.LoadOptions.Sources {
+		definitions[source.Name] = source
+	}
 
-	where := storage.WhereNotSent()
+	where := storage.WhereNotSent(opt.Destinations...)
+
+	queued, err := queuedHashes(opt)
+	if err != nil {
+		return Result[T]{}, err
+	}
 
 	for _, entry := range loadedEntries {
 		grouped[entry.Source()] = append(grouped[entry.Source()], entry)
 	}
 
+	names := make([]string, 0, len(grouped))
+
+	for source := range grouped {
+		names = append(names, source)
+	}
+
+	// a stable order keeps the deduplication and the selection reproducible
+	sort.Strings(names)
+
 	results := SourceResultEntriesList[T]{}
 
-	for source, entries := range grouped {
+	for _, source := range names {
+		entries := grouped[source]
 		total := len(entries)
-		entries, err := opt.Storage.Where(where, entries)
+		entries, err := opt.Storage.Where(where, filters.Apply(opt.Filters, entries))
 		if err != nil {
 			return Result[T]{}, err
 		}
 
+		entries, err = skipQueued(queued, entries)
+		if err != nil {
+			return Result[T]{}, err
+		}
+
+		entries = FilterByAge(entries, opt.MaxAge)
+
 		result := SourceResultEntries[T]{
-			Entries: entries,
+			Entries:  entries,
+			Weight:   definitions[source].Weight,
+			Priority: definitions[source].Priority,
 			SourceResult: SourceResult{
 				Total:    total,
 				Source:   source,
@@ -93,31 +188,102 @@ func buildResult[T model.IEntry](ctx context.Context, opt LoadOptions[T], loaded
 		results = append(results, result)
 	}
 
-	entries := results.Limit(ctx, opt.Limit)
+	results, err = dedupe(ctx, opt, results)
+	if err != nil {
+		return Result[T]{}, err
+	}
+
+	entries := results.Select(ctx, Selection{
+		Limit:        opt.Limit,
+		MaxPerSource: opt.MaxPerSource,
+		Seed:         opt.Seed,
+	})
 
 	return Result[T]{
-		Entries:  entries,
-		Loaded:   len(loadedEntries),
-		Filtered: len(entries),
-		Results:  results.SourceResults(),
+		Entries:     entries,
+		Loaded:      len(loadedEntries),
+		Filtered:    len(entries),
+		Duplicates:  results.Duplicates(),
+		Results:     results.SourceResults(),
+		Failed:      []string{},
+		Quarantined: []storage.SourceHealth{},
 	}, nil
 }
 
-func (r SourceResultEntriesList[T]) Limit(ctx context.Context, limit int) []T {
-	entries := []T{}
+func queuedHashes[T model.IEntry](opt LoadOptions[T]) (map[string]bool, error) {
+	hashes := map[string]bool{}
 
-	for _, result := range r {
-		entries = append(entries, result.Entries...)
+	if !opt.SkipQueued {
+		return hashes, nil
 	}
 
-	entries = support.Shuffle(entries)
+	items, err := opt.Storage.Queue(storage.FindQueueOptions{Until: time.Time{}, Limit: 0})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range items {
+		hash, err := item.Data.Hash()
+		if err != nil {
+			return nil, err
+		}
+
+		hashes[hash] = true
+	}
+
+	return hashes, nil
+}
 
-	if limit > 0 && len(entries) > limit {
-		zerolog.Ctx(ctx).Info().Int("limit", limit).Msg("limiting entries")
-		entries = entries[:limit]
+func skipQueued[T model.IEntry](queued map[string]bool, entries []T) ([]T, error) {
+	if len(queued) == 0 {
+		return entries, nil
 	}
 
-	return entries
+	result := []T{}
+
+	for _, entry := range entries {
+		hash, err := entry.Hash()
+		if err != nil {
+			return nil, err
+		}
+
+		if !queued[hash] {
+			result = append(result, entry)
+		}
+	}
+
+	return result, nil
+}
+
+// FilterByAge removes entries published before maxAge.
+// Entries without a published date are kept.
+func FilterByAge[T model.IEntry](entries []T, maxAge time.Duration) []T {
+	if maxAge <= 0 {
+		return entries
+	}
+
+	since := time.Now().Add(-maxAge)
+	result := []T{}
+
+	for _, entry := range entries {
+		published := entry.Published()
+
+		if published.IsZero() || published.After(since) {
+			result = append(result, entry)
+		}
+	}
+
+	return result
+}
+
+func (r SourceResultEntriesList[T]) Duplicates() int {
+	total := 0
+
+	for _, result := range r {
+		total += result.Duplicates
+	}
+
+	return total
 }
 
 func (r SourceResultEntriesList[T]) SourceResults() []SourceResult {
@@ -141,9 +307,22 @@ func (r Result[T]) Resume() sender.Resume {
 		}
 	}
 
+	quarantined := make([]sender.ResumeQuarantine, len(r.Quarantined))
+
+	for index, health := range r.Quarantined {
+		quarantined[index] = sender.ResumeQuarantine{
+			Source:   health.Source,
+			Failures: health.ConsecutiveFailures,
+			Until:    health.QuarantinedUntil,
+		}
+	}
+
 	return sender.Resume{
-		Loaded:   r.Loaded,
-		Filtered: r.Filtered,
-		Sources:  sources,
+		Loaded:      r.Loaded,
+		Filtered:    r.Filtered,
+		Duplicates:  r.Duplicates,
+		Sources:     sources,
+		Failed:      r.Failed,
+		Quarantined: quarantined,
 	}
 }
@@ -0,0 +1,36 @@
+package news_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vinicius73/gear-feed/pkg/linkloader/news"
+	"github.com/vinicius73/gear-feed/pkg/model"
+)
+
+func TestFilterByAge(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	entries := []model.Entry{
+		{Title: "no date"},
+		{Title: "fresh", PublishedAt: now.Add(-time.Hour)},
+		{Title: "old", PublishedAt: now.Add(-time.Hour * 72)},
+	}
+
+	titles := func(list []model.Entry) []string {
+		result := []string{}
+
+		for _, entry := range list {
+			result = append(result, entry.Title)
+		}
+
+		return result
+	}
+
+	assert.Equal(t, []string{"no date", "fresh", "old"}, titles(news.FilterByAge(entries, 0)))
+	assert.Equal(t, []string{"no date", "fresh"}, titles(news.FilterByAge(entries, time.Hour*24)))
+	assert.Equal(t, []string{"no date"}, titles(news.FilterByAge(entries, time.Minute)))
+}
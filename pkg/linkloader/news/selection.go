@@ -0,0 +1,119 @@
+package news
+
+import (
+	"context"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/vinicius73/gear-feed/pkg/model"
+)
+
+const defaultWeight = 1
+
+// Selection of the entries to send, the sources are interleaved by their weights.
+type Selection struct {
+	Limit int
+	// MaxPerSource limits the entries of each source, zero means no limit.
+	MaxPerSource int
+	// Seed makes the selection deterministic, a random seed is used when zero.
+	Seed int64
+}
+
+type sourceQueue[T model.IEntry] struct {
+	source   string
+	entries  []T
+	weight   int
+	priority int
+	current  int
+	picked   int
+}
+
+func (q *sourceQueue[T]) available(maxPerSource int) bool {
+	return q.picked < len(q.entries) && (maxPerSource <= 0 || q.picked < maxPerSource)
+}
+
+// Select picks the entries by smooth weighted round robin, a source with weight 2 is picked
+// twice as often as one with weight 1. The ties are broken by the priority of the sources.
+func (r SourceResultEntriesList[T]) Select(ctx context.Context, opt Selection) []T {
+	seed := opt.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	rng := rand.New(rand.NewSource(seed)) //nolint:gosec
+
+	queues := make([]*sourceQueue[T], 0, len(r))
+	total := 0
+
+	for _, result := range r {
+		entries := append([]T{}, result.Entries...)
+		rng.Shuffle(len(entries), func(i, j int) { entries[i], entries[j] = entries[j], entries[i] })
+
+		weight := result.Weight
+		if weight <= 0 {
+			weight = defaultWeight
+		}
+
+		queues = append(queues, &sourceQueue[T]{
+			source:   result.Source,
+			entries:  entries,
+			weight:   weight,
+			priority: result.Priority,
+			current:  0,
+			picked:   0,
+		})
+
+		total += len(entries)
+	}
+
+	// the sources of the same priority are picked in random order
+	sort.Slice(queues, func(i, j int) bool { return queues[i].source < queues[j].source })
+	rng.Shuffle(len(queues), func(i, j int) { queues[i], queues[j] = queues[j], queues[i] })
+	sort.SliceStable(queues, func(i, j int) bool { return queues[i].priority > queues[j].priority })
+
+	limit := opt.Limit
+	if limit <= 0 || limit > total {
+		limit = total
+	}
+
+	entries := []T{}
+
+	for len(entries) < limit {
+		var best *sourceQueue[T]
+
+		weights := 0
+
+		for _, queue := range queues {
+			if !queue.available(opt.MaxPerSource) {
+				continue
+			}
+
+			queue.current += queue.weight
+			weights += queue.weight
+
+			if best == nil || queue.current > best.current {
+				best = queue
+			}
+		}
+
+		if best == nil {
+			break
+		}
+
+		best.current -= weights
+		entries = append(entries, best.entries[best.picked])
+		best.picked++
+	}
+
+	if len(entries) < total {
+		zerolog.Ctx(ctx).Info().
+			Int("limit", opt.Limit).
+			Int("max_per_source", opt.MaxPerSource).
+			Int("selected", len(entries)).
+			Msg("limiting entries")
+	}
+
+	return entries
+}
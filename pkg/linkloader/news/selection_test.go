@@ -0,0 +1,100 @@
+package news_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vinicius73/gear-feed/pkg/linkloader/news"
+	"github.com/vinicius73/gear-feed/pkg/model"
+)
+
+func sourceEntries(source string, size, weight, priority int) news.SourceResultEntries[model.Entry] {
+	entries := []model.Entry{}
+
+	for index := range size {
+		entries = append(entries, model.Entry{Title: source + "-" + strconv.Itoa(index), SourceName: source})
+	}
+
+	return news.SourceResultEntries[model.Entry]{
+		SourceResult: news.SourceResult{Source: source, Total: size, Filtered: size, Duplicates: 0},
+		Entries:      entries,
+		Weight:       weight,
+		Priority:     priority,
+	}
+}
+
+func countSources(entries []model.Entry) map[string]int {
+	counts := map[string]int{}
+
+	for _, entry := range entries {
+		counts[entry.SourceName]++
+	}
+
+	return counts
+}
+
+func TestSelectDiversity(t *testing.T) {
+	t.Parallel()
+
+	results := news.SourceResultEntriesList[model.Entry]{
+		sourceEntries("NOISY", 30, 0, 0),
+		sourceEntries("CURATED", 2, 0, 0),
+		sourceEntries("OTHER", 3, 0, 0),
+	}
+
+	entries := results.Select(context.TODO(), news.Selection{Limit: 3, MaxPerSource: 0, Seed: 42})
+
+	assert.Equal(t, map[string]int{"NOISY": 1, "CURATED": 1, "OTHER": 1}, countSources(entries))
+
+	// all the entries are returned without limit
+	assert.Len(t, results.Select(context.TODO(), news.Selection{Limit: 0, MaxPerSource: 0, Seed: 42}), 35)
+}
+
+func TestSelectWeight(t *testing.T) {
+	t.Parallel()
+
+	results := news.SourceResultEntriesList[model.Entry]{
+		sourceEntries("HEAVY", 10, 3, 0),
+		sourceEntries("LIGHT", 10, 1, 0),
+	}
+
+	entries := results.Select(context.TODO(), news.Selection{Limit: 8, MaxPerSource: 0, Seed: 1})
+
+	assert.Equal(t, map[string]int{"HEAVY": 6, "LIGHT": 2}, countSources(entries))
+
+	entries = results.Select(context.TODO(), news.Selection{Limit: 8, MaxPerSource: 4, Seed: 1})
+
+	assert.Equal(t, map[string]int{"HEAVY": 4, "LIGHT": 4}, countSources(entries))
+}
+
+func TestSelectPriority(t *testing.T) {
+	t.Parallel()
+
+	results := news.SourceResultEntriesList[model.Entry]{
+		sourceEntries("A", 5, 0, 0),
+		sourceEntries("B", 5, 0, 10),
+		sourceEntries("C", 5, 0, 0),
+	}
+
+	for seed := range int64(10) {
+		entries := results.Select(context.TODO(), news.Selection{Limit: 1, MaxPerSource: 0, Seed: seed + 1})
+
+		assert.Equal(t, "B", entries[0].SourceName)
+	}
+}
+
+func TestSelectSeed(t *testing.T) {
+	t.Parallel()
+
+	results := news.SourceResultEntriesList[model.Entry]{
+		sourceEntries("A", 10, 2, 0),
+		sourceEntries("B", 10, 1, 0),
+		sourceEntries("C", 10, 1, 0),
+	}
+
+	selection := news.Selection{Limit: 6, MaxPerSource: 0, Seed: 7}
+
+	assert.Equal(t, results.Select(context.TODO(), selection), results.Select(context.TODO(), selection))
+}
@@ -0,0 +1,255 @@
+package mastodon
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/vinicius73/gear-feed/pkg/support/apperrors"
+)
+
+const (
+	requestTimeout = time.Second * 120
+	mediaPolling   = time.Second
+	mediaTimeout   = time.Minute * 2
+	// StatusLimit is the default characters limit of a status.
+	StatusLimit = 500
+)
+
+var (
+	ErrNotConfigured  = apperrors.Business("mastodon server and token are required", "MASTODON:NOT_CONFIGURED")
+	ErrRequestFailed  = apperrors.System(nil, "mastodon request failed", "MASTODON:REQUEST_FAILED")
+	ErrMediaNotReady  = apperrors.Business("mastodon media was not processed in time: %s", "MASTODON:MEDIA_NOT_READY")
+	ErrInvalidPayload = apperrors.System(nil, "invalid mastodon response", "MASTODON:INVALID_PAYLOAD")
+)
+
+type Visibility string
+
+const (
+	VisibilityPublic   Visibility = "public"
+	VisibilityUnlisted Visibility = "unlisted"
+	VisibilityPrivate  Visibility = "private"
+	VisibilityDirect   Visibility = "direct"
+)
+
+type Config struct {
+	// Server is the instance URL, like https://mastodon.social
+	Server     string     `fig:"server"     yaml:"server"`
+	Token      string     `fig:"token"      yaml:"token"`
+	Visibility Visibility `fig:"visibility" yaml:"visibility"`
+	Language   string     `fig:"language"   yaml:"language"`
+}
+
+func (c Config) Enabled() bool {
+	return c.Server != "" && c.Token != ""
+}
+
+type Status struct {
+	Status         string     `json:"status"`
+	MediaIDs       []string   `json:"media_ids,omitempty"`
+	Visibility     Visibility `json:"visibility,omitempty"`
+	Language       string     `json:"language,omitempty"`
+	IdempotencyKey string     `json:"-"`
+}
+
+type Posted struct {
+	ID  string `json:"id"`
+	URL string `json:"url"`
+}
+
+type Attachment struct {
+	ID   string  `json:"id"`
+	Type string  `json:"type"`
+	URL  *string `json:"url"`
+}
+
+type Client struct {
+	http       *http.Client
+	server     string
+	token      string
+	visibility Visibility
+	language   string
+}
+
+func NewClient(cfg Config) Client {
+	return Client{
+		//nolint:exhaustruct
+		http:       &http.Client{Timeout: requestTimeout},
+		server:     strings.TrimSuffix(cfg.Server, "/"),
+		token:      cfg.Token,
+		visibility: cfg.Visibility,
+		language:   cfg.Language,
+	}
+}
+
+// PostStatus publishes the status, using the configured visibility and language as default.
+func (c Client) PostStatus(ctx context.Context, status Status) (Posted, error) {
+	var posted Posted
+
+	if status.Visibility == "" {
+		status.Visibility = c.visibility
+	}
+
+	if status.Language == "" {
+		status.Language = c.language
+	}
+
+	payload, err := json.Marshal(status)
+	if err != nil {
+		return posted, ErrRequestFailed.Wrap(err)
+	}
+
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+
+	if status.IdempotencyKey != "" {
+		header.Set("Idempotency-Key", status.IdempotencyKey)
+	}
+
+	err = c.do(ctx, http.MethodPost, "/api/v1/statuses", bytes.NewReader(payload), header, &posted)
+
+	return posted, err
+}
+
+// UploadFile uploads a local file as media attachment.
+func (c Client) UploadFile(ctx context.Context, file, description string) (Attachment, error) {
+	opened, err := os.Open(file)
+	if err != nil {
+		return Attachment{}, ErrRequestFailed.Wrap(err)
+	}
+
+	defer opened.Close()
+
+	return c.Upload(ctx, filepath.Base(file), opened, description)
+}
+
+// UploadURL downloads the remote file and uploads it as media attachment.
+func (c Client) UploadURL(ctx context.Context, url, description string) (Attachment, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return Attachment{}, ErrRequestFailed.Wrap(err)
+	}
+
+	res, err := c.http.Do(req)
+	if err != nil {
+		return Attachment{}, ErrRequestFailed.Wrap(err)
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return Attachment{}, ErrRequestFailed.Wrap(fmt.Errorf("download %s: status %d", url, res.StatusCode)) //nolint:err113
+	}
+
+	name := path.Base(req.URL.Path)
+
+	if name == "" || name == "/" || name == "." {
+		name = "image"
+	}
+
+	return c.Upload(ctx, name, res.Body, description)
+}
+
+// Upload sends the media, waiting until the server finishes its processing.
+func (c Client) Upload(ctx context.Context, name string, content io.Reader, description string) (Attachment, error) {
+	var (
+		attachment Attachment
+		buf        bytes.Buffer
+	)
+
+	writer := multipart.NewWriter(&buf)
+
+	part, err := writer.CreateFormFile("file", name)
+	if err != nil {
+		return attachment, ErrRequestFailed.Wrap(err)
+	}
+
+	if _, err = io.Copy(part, content); err != nil {
+		return attachment, ErrRequestFailed.Wrap(err)
+	}
+
+	if description != "" {
+		if err = writer.WriteField("description", description); err != nil {
+			return attachment, ErrRequestFailed.Wrap(err)
+		}
+	}
+
+	if err = writer.Close(); err != nil {
+		return attachment, ErrRequestFailed.Wrap(err)
+	}
+
+	header := http.Header{}
+	header.Set("Content-Type", writer.FormDataContentType())
+
+	if err = c.do(ctx, http.MethodPost, "/api/v2/media", &buf, header, &attachment); err != nil {
+		return attachment, err
+	}
+
+	return c.waitMedia(ctx, attachment)
+}
+
+// waitMedia polls the attachment until its URL is available, large files are processed asynchronously.
+func (c Client) waitMedia(ctx context.Context, attachment Attachment) (Attachment, error) {
+	deadline := time.Now().Add(mediaTimeout)
+
+	for attachment.URL == nil {
+		if time.Now().After(deadline) {
+			return attachment, ErrMediaNotReady.Msgf(attachment.ID)
+		}
+
+		select {
+		case <-ctx.Done():
+			return attachment, ctx.Err()
+		case <-time.After(mediaPolling):
+		}
+
+		if err := c.do(ctx, http.MethodGet, "/api/v1/media/"+attachment.ID, nil, http.Header{}, &attachment); err != nil {
+			return attachment, err
+		}
+	}
+
+	return attachment, nil
+}
+
+func (c Client) do(ctx context.Context, method, endpoint string, body io.Reader, header http.Header, target any) error {
+	req, err := http.NewRequestWithContext(ctx, method, c.server+endpoint, body)
+	if err != nil {
+		return ErrRequestFailed.Wrap(err)
+	}
+
+	req.Header = header
+	req.Header.Set("Authorization", "Bearer "+c.token)
+
+	res, err := c.http.Do(req)
+	if err != nil {
+		return ErrRequestFailed.Wrap(err)
+	}
+
+	defer res.Body.Close()
+
+	content, _ := io.ReadAll(res.Body)
+
+	// 206 is returned while a media is being processed
+	if res.StatusCode == http.StatusPartialContent {
+		return nil
+	}
+
+	if res.StatusCode >= http.StatusBadRequest {
+		return ErrRequestFailed.Wrap(fmt.Errorf("%s %s: status %d: %s", method, endpoint, res.StatusCode, content)) //nolint:err113
+	}
+
+	if err = json.Unmarshal(content, target); err != nil {
+		return ErrInvalidPayload.Wrap(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package mastodon_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vinicius73/gear-feed/pkg/mastodon"
+)
+
+func TestPostStatus(t *testing.T) {
+	t.Parallel()
+
+	var received map[string]any
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/statuses", r.URL.Path)
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		assert.Equal(t, "key-1", r.Header.Get("Idempotency-Key"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+
+		_, _ = w.Write([]byte(`{"id": "100", "url": "https://social.test/@gfeed/100"}`))
+	}))
+
+	defer server.Close()
+
+	client := mastodon.NewClient(mastodon.Config{
+		Server:     server.URL + "/",
+		Token:      "secret",
+		Visibility: mastodon.VisibilityUnlisted,
+		Language:   "pt",
+	})
+
+	posted, err := client.PostStatus(context.TODO(), mastodon.Status{
+		Status:         "Hello",
+		MediaIDs:       []string{"1"},
+		IdempotencyKey: "key-1",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, mastodon.Posted{ID: "100", URL: "https://social.test/@gfeed/100"}, posted)
+	assert.Equal(t, map[string]any{
+		"status":     "Hello",
+		"media_ids":  []any{"1"},
+		"visibility": "unlisted",
+		"language":   "pt",
+	}, received)
+}
+
+func TestUploadFileAsync(t *testing.T) {
+	t.Parallel()
+
+	file := filepath.Join(t.TempDir(), "story.mp4")
+
+	assert.NoError(t, os.WriteFile(file, []byte("video-data"), 0o600))
+
+	var polls atomic.Int32
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("POST /api/v2/media", func(w http.ResponseWriter, r *http.Request) {
+		upload, header, err := r.FormFile("file")
+		assert.NoError(t, err)
+
+		content, _ := io.ReadAll(upload)
+
+		assert.Equal(t, "story.mp4", header.Filename)
+		assert.Equal(t, "video-data", string(content))
+		assert.Equal(t, "Entry 1", r.FormValue("description"))
+
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"id": "7", "type": "video", "url": null}`))
+	})
+
+	mux.HandleFunc("GET /api/v1/media/7", func(w http.ResponseWriter, _ *http.Request) {
+		if polls.Add(1) == 1 {
+			w.WriteHeader(http.StatusPartialContent)
+			_, _ = w.Write([]byte(`{"id": "7", "type": "video", "url": null}`))
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"id": "7", "type": "video", "url": "https://social.test/7.mp4"}`))
+	})
+
+	server := httptest.NewServer(mux)
+
+	defer server.Close()
+
+	client := mastodon.NewClient(mastodon.Config{Server: server.URL, Token: "secret"})
+
+	media, err := client.UploadFile(context.TODO(), file, "Entry 1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "7", media.ID)
+	assert.Equal(t, "https://social.test/7.mp4", *media.URL)
+	assert.Equal(t, int32(2), polls.Load())
+}
+
+func TestRequestFailed(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"error": "Validation failed: Text can't be blank"}`))
+	}))
+
+	defer server.Close()
+
+	_, err := mastodon.NewClient(mastodon.Config{Server: server.URL, Token: "secret"}).
+		PostStatus(context.TODO(), mastodon.Status{})
+
+	assert.ErrorContains(t, err, mastodon.ErrRequestFailed.ErrorCode)
+	assert.ErrorContains(t, err, "Text can't be blank")
+}
+
+func TestHashtag(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "GameVicio", mastodon.Hashtag("Game-Vicio"))
+	assert.Equal(t, "jogos_ps5", mastodon.Hashtag("jogos_ps5"))
+	assert.Equal(t, "ação", mastodon.Hashtag("ação!"))
+	assert.Empty(t, mastodon.Hashtag("..."))
+}
@@ -0,0 +1,33 @@
+package mastodon
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/vinicius73/gear-feed/pkg/support"
+)
+
+var reTags = regexp.MustCompile(`<[^>]+>`)
+
+// Hashtag keeps only the characters allowed in a mastodon hashtag.
+func Hashtag(tag string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			return r
+		}
+
+		return -1
+	}, tag)
+}
+
+// Truncate text to limit runes.
+func Truncate(text string, limit int) string {
+	return support.Truncate(text, limit)
+}
+
+// FromHTML converts the telegram HTML used by the messages into plain text.
+func FromHTML(input string) string {
+	return html.UnescapeString(reTags.ReplaceAllString(input, ""))
+}
@@ -0,0 +1,39 @@
+package metrics
+
+import "time"
+
+const (
+	ResultSuccess = "success"
+	ResultFailure = "failure"
+)
+
+var (
+	ScrapeDuration = Default.Histogram(
+		"gfeed_scrape_duration_seconds", "Time spent scraping a source.", nil, "source", "result")
+	ScrapeEntries = Default.Gauge(
+		"gfeed_scrape_entries", "Entries found by the last scrape of a source.", "source")
+	SendTotal = Default.Counter(
+		"gfeed_send_total", "Deliveries made by the senders.", "destination", "kind", "result")
+	StoryBuildDuration = Default.Histogram(
+		"gfeed_story_build_duration_seconds", "Time spent building a story video.", nil, "result")
+	CleanupEntries = Default.Counter(
+		"gfeed_cleanup_entries_total", "Entries removed by the cleanup task.")
+	TaskRuns = Default.Counter(
+		"gfeed_task_runs_total", "Executions of cron tasks.", "task", "result")
+	TaskDuration = Default.Histogram(
+		"gfeed_task_duration_seconds", "Time spent running a cron task.", nil, "task")
+)
+
+// Result returns the result label of an operation.
+func Result(err error) string {
+	if err != nil {
+		return ResultFailure
+	}
+
+	return ResultSuccess
+}
+
+// Since returns the elapsed seconds, used to observe durations.
+func Since(start time.Time) float64 {
+	return time.Since(start).Seconds()
+}
@@ -0,0 +1,263 @@
+package metrics
+
+import (
+	"bufio"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ContentType of the Prometheus text exposition format.
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+const labelSeparator = "\xff"
+
+// DefaultBuckets are used by histograms created without buckets, in seconds.
+var DefaultBuckets = []float64{0.1, 0.5, 1, 2.5, 5, 10, 30, 60, 120, 300}
+
+// Default is the registry used by the application metrics.
+var Default = NewRegistry()
+
+type kind string
+
+const (
+	kindCounter   kind = "counter"
+	kindGauge     kind = "gauge"
+	kindHistogram kind = "histogram"
+)
+
+// Registry keeps the metrics exposed by the handler.
+type Registry struct {
+	mu      sync.Mutex
+	metrics []*metric
+}
+
+type metric struct {
+	mu      sync.Mutex
+	name    string
+	help    string
+	kind    kind
+	labels  []string
+	buckets []float64
+	series  map[string]*series
+}
+
+type series struct {
+	values []string
+	value  float64
+	counts []uint64
+	count  uint64
+}
+
+type Counter struct{ metric *metric }
+
+type Gauge struct{ metric *metric }
+
+type Histogram struct{ metric *metric }
+
+func NewRegistry() *Registry {
+	return &Registry{mu: sync.Mutex{}, metrics: []*metric{}}
+}
+
+func (r *Registry) Counter(name, help string, labels ...string) Counter {
+	return Counter{metric: r.register(name, help, kindCounter, labels, nil)}
+}
+
+func (r *Registry) Gauge(name, help string, labels ...string) Gauge {
+	return Gauge{metric: r.register(name, help, kindGauge, labels, nil)}
+}
+
+func (r *Registry) Histogram(name, help string, buckets []float64, labels ...string) Histogram {
+	if len(buckets) == 0 {
+		buckets = DefaultBuckets
+	}
+
+	buckets = append([]float64{}, buckets...)
+	sort.Float64s(buckets)
+
+	return Histogram{metric: r.register(name, help, kindHistogram, labels, buckets)}
+}
+
+func (r *Registry) register(name, help string, kind kind, labels []string, buckets []float64) *metric {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	item := &metric{
+		mu:      sync.Mutex{},
+		name:    name,
+		help:    help,
+		kind:    kind,
+		labels:  labels,
+		buckets: buckets,
+		series:  map[string]*series{},
+	}
+
+	r.metrics = append(r.metrics, item)
+
+	return item
+}
+
+// Inc adds one to the counter, label values must follow the declared labels.
+func (c Counter) Inc(values ...string) {
+	c.Add(1, values...)
+}
+
+func (c Counter) Add(val float64, values ...string) {
+	c.metric.update(values, func(s *series) {
+		s.value += val
+	})
+}
+
+func (g Gauge) Set(val float64, values ...string) {
+	g.metric.update(values, func(s *series) {
+		s.value = val
+	})
+}
+
+func (h Histogram) Observe(val float64, values ...string) {
+	h.metric.update(values, func(s *series) {
+		if s.counts == nil {
+			s.counts = make([]uint64, len(h.metric.buckets))
+		}
+
+		for index, bucket := range h.metric.buckets {
+			if val <= bucket {
+				s.counts[index]++
+			}
+		}
+
+		s.count++
+		s.value += val
+	})
+}
+
+func (m *metric) update(values []string, fn func(s *series)) {
+	// missing values are exposed as empty labels
+	if len(values) < len(m.labels) {
+		values = append(values, make([]string, len(m.labels)-len(values))...)
+	}
+
+	values = values[:len(m.labels)]
+	key := strings.Join(values, labelSeparator)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	item, ok := m.series[key]
+	if !ok {
+		item = &series{values: append([]string{}, values...), value: 0, counts: nil, count: 0}
+		m.series[key] = item
+	}
+
+	fn(item)
+}
+
+// WriteTo writes all metrics using the text exposition format.
+func (r *Registry) WriteTo(w io.Writer) (int64, error) {
+	r.mu.Lock()
+	list := append([]*metric{}, r.metrics...)
+	r.mu.Unlock()
+
+	counter := &countWriter{writer: w, count: 0}
+	buf := bufio.NewWriter(counter)
+
+	for _, item := range list {
+		item.write(buf)
+	}
+
+	err := buf.Flush()
+
+	return counter.count, err
+}
+
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", ContentType)
+
+		_, _ = r.WriteTo(w)
+	})
+}
+
+func (m *metric) write(w *bufio.Writer) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	w.WriteString("# HELP " + m.name + " " + m.help + "\n")
+	w.WriteString("# TYPE " + m.name + " " + string(m.kind) + "\n")
+
+	keys := make([]string, 0, len(m.series))
+
+	for key := range m.series {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		item := m.series[key]
+
+		if m.kind != kindHistogram {
+			writeSample(w, m.name, m.labels, item.values, "", item.value)
+
+			continue
+		}
+
+		for index, bucket := range m.buckets {
+			writeSample(w, m.name+"_bucket", m.labels, item.values, formatFloat(bucket), float64(item.counts[index]))
+		}
+
+		writeSample(w, m.name+"_bucket", m.labels, item.values, "+Inf", float64(item.count))
+		writeSample(w, m.name+"_sum", m.labels, item.values, "", item.value)
+		writeSample(w, m.name+"_count", m.labels, item.values, "", float64(item.count))
+	}
+}
+
+func writeSample(w *bufio.Writer, name string, labels, values []string, le string, val float64) {
+	pairs := make([]string, 0, len(labels)+1)
+
+	for index, label := range labels {
+		pairs = append(pairs, label+`="`+escape(values[index])+`"`)
+	}
+
+	if le != "" {
+		pairs = append(pairs, `le="`+le+`"`)
+	}
+
+	w.WriteString(name)
+
+	if len(pairs) > 0 {
+		w.WriteString("{" + strings.Join(pairs, ",") + "}")
+	}
+
+	w.WriteString(" " + formatFloat(val) + "\n")
+}
+
+var escaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escape(val string) string {
+	return escaper.Replace(val)
+}
+
+func formatFloat(val float64) string {
+	if math.IsInf(val, 1) {
+		return "+Inf"
+	}
+
+	return strconv.FormatFloat(val, 'g', -1, 64)
+}
+
+type countWriter struct {
+	writer io.Writer
+	count  int64
+}
+
+func (c *countWriter) Write(p []byte) (int, error) {
+	n, err := c.writer.Write(p)
+	c.count += int64(n)
+
+	return n, err
+}
@@ -0,0 +1,64 @@
+package metrics_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vinicius73/gear-feed/pkg/metrics"
+)
+
+func TestRegistryWriteTo(t *testing.T) {
+	t.Parallel()
+
+	registry := metrics.NewRegistry()
+
+	counter := registry.Counter("test_send_total", "Sent messages.", "destination", "result")
+	gauge := registry.Gauge("test_entries", "Found entries.")
+	histogram := registry.Histogram("test_duration_seconds", "Duration.", []float64{1, 0.5}, "source")
+
+	counter.Inc("telegram", "success")
+	counter.Add(2, "telegram", "success")
+	counter.Inc(`say "hi"`, "failure")
+	gauge.Set(7)
+	histogram.Observe(0.3, "demo")
+	histogram.Observe(0.8, "demo")
+	histogram.Observe(3, "demo")
+
+	var buf bytes.Buffer
+
+	_, err := registry.WriteTo(&buf)
+
+	assert.NoError(t, err)
+	assert.Equal(t, `# HELP test_send_total Sent messages.
+# TYPE test_send_total counter
+test_send_total{destination="say \"hi\"",result="failure"} 1
+test_send_total{destination="telegram",result="success"} 3
+# HELP test_entries Found entries.
+# TYPE test_entries gauge
+test_entries 7
+# HELP test_duration_seconds Duration.
+# TYPE test_duration_seconds histogram
+test_duration_seconds_bucket{source="demo",le="0.5"} 1
+test_duration_seconds_bucket{source="demo",le="1"} 2
+test_duration_seconds_bucket{source="demo",le="+Inf"} 3
+test_duration_seconds_sum{source="demo"} 4.1
+test_duration_seconds_count{source="demo"} 3
+`, buf.String())
+}
+
+func TestRegistryHandler(t *testing.T) {
+	t.Parallel()
+
+	registry := metrics.NewRegistry()
+	registry.Counter("test_total", "Test.").Inc()
+
+	rec := httptest.NewRecorder()
+	registry.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, metrics.ContentType, rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Body.String(), "test_total 1\n")
+}
@@ -1,6 +1,8 @@
 package model
 
 import (
+	"time"
+
 	"github.com/vinicius73/gear-feed/pkg/support"
 )
 
@@ -12,25 +14,36 @@ type IEntry interface {
 	Tags() []string
 	Source() string
 	ImageURL() string
+	Published() time.Time
 	Hash() (string, error)
 	HasStory() bool
 	SetHasStory(bool) IEntry
+	Cluster() string
+	SetCluster(string) IEntry
+	Summary() string
+	Site() string
 
 	FillFrom(IEntry) IEntry
 }
 
 type Entry struct {
-	Title      string   `json:"title"`
-	URL        string   `json:"url"`
-	Image      string   `json:"image_url"`
-	Categories []string `json:"categories"`
-	SourceName string   `json:"source"`
-	HaveStory  bool     `json:"has_story"`
+	Title       string    `json:"title"`
+	URL         string    `json:"url"`
+	Image       string    `json:"image_url"`
+	Categories  []string  `json:"categories"`
+	SourceName  string    `json:"source"`
+	HaveStory   bool      `json:"has_story"`
+	PublishedAt time.Time `json:"published_at"`
+	// Description and SiteName are filled by the article enrichment.
+	Description string `json:"description,omitempty"`
+	SiteName    string `json:"site_name,omitempty"`
+	// ClusterID groups the entries about the same story.
+	ClusterID string `json:"cluster,omitempty"`
 }
 
-// Hash of entry.
+// Hash of entry, the variants of the same URL have the same hash.
 func (e Entry) Hash() (string, error) {
-	return support.HashSHA256(e.URL)
+	return support.HashSHA256(HashKey(e.URL))
 }
 
 func (e Entry) Text() string {
@@ -41,6 +54,11 @@ func (e Entry) ImageURL() string {
 	return e.Image
 }
 
+// Published date of entry, zero when the source does not provide it.
+func (e Entry) Published() time.Time {
+	return e.PublishedAt
+}
+
 func (e Entry) Link() string {
 	return e.URL
 }
@@ -63,18 +81,41 @@ func (e Entry) SetHasStory(hasStory bool) IEntry {
 	return e
 }
 
+func (e Entry) Cluster() string {
+	return e.ClusterID
+}
+
+func (e Entry) SetCluster(cluster string) IEntry {
+	e.ClusterID = cluster
+
+	return e
+}
+
+// Summary is the description of the article, empty when the entry is not enriched.
+func (e Entry) Summary() string {
+	return e.Description
+}
+
+func (e Entry) Site() string {
+	return e.SiteName
+}
+
 func (e Entry) FillFrom(input IEntry) IEntry {
 	if actual, ok := input.(Entry); ok {
 		return actual
 	}
 
 	e = Entry{
-		Title:      input.Text(),
-		URL:        input.Link(),
-		Image:      input.ImageURL(),
-		Categories: input.Tags(),
-		SourceName: input.Source(),
-		HaveStory:  input.HasStory(),
+		Title:       input.Text(),
+		URL:         input.Link(),
+		Image:       input.ImageURL(),
+		Categories:  input.Tags(),
+		SourceName:  input.Source(),
+		HaveStory:   input.HasStory(),
+		PublishedAt: input.Published(),
+		Description: input.Summary(),
+		SiteName:    input.Site(),
+		ClusterID:   input.Cluster(),
 	}
 
 	return e
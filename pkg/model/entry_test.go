@@ -24,7 +24,7 @@ func TestEntry_Hash(t *testing.T) {
 				Categories: []string{"review"},
 				SourceName: "gamereactor",
 			},
-			want: "9311deeecac5fb039a8e3f6102659821f103ee48f486c55ce7c3868151ee25aa",
+			want: "fa827a7ead53a45416f6d8840f234ac79cd3122775afd794fcf659817d993558",
 		},
 		{
 			input: model.Entry{
@@ -34,7 +34,15 @@ func TestEntry_Hash(t *testing.T) {
 				Categories: []string{"review"},
 				SourceName: "gamereactor",
 			},
-			want: "d3be2aa13ed6d3b659e5536016e1351e3e5ac2f85088e80e13524aa706c693e0",
+			want: "e40e1ccc8d675b46149d1aee7ad6bed36eeb62bb2c388e704097e263dc78d65a",
+		},
+		{
+			// tracking parameters, fragment and scheme are ignored
+			input: model.Entry{
+				Title: "The Last of Us Part II",
+				URL:   "http://www.gamereactor.eu/the-last-of-us-part-ii-review?utm_source=twitter&fbclid=abc#comments",
+			},
+			want: "fa827a7ead53a45416f6d8840f234ac79cd3122775afd794fcf659817d993558",
 		},
 	}
 
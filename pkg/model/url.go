@@ -0,0 +1,113 @@
+package model
+
+import (
+	"net/url"
+	"strings"
+)
+
+// DefaultStripParams are removed from all URLs, a trailing "*" matches the prefix.
+var DefaultStripParams = []string{
+	"utm_*",
+	"fbclid",
+	"gclid",
+	"dclid",
+	"igshid",
+	"mc_cid",
+	"mc_eid",
+	"_ga",
+	"ref_src",
+	"amp",
+	"outputtype",
+}
+
+// CanonicalURL removes the tracking parameters, the fragment and the AMP variant of the link,
+// the parameters are removed along with the DefaultStripParams.
+// Relative or invalid links are only trimmed.
+func CanonicalURL(link string, strip ...string) string {
+	link = strings.TrimSpace(link)
+
+	parsed, err := url.Parse(link)
+	if err != nil || parsed.Host == "" {
+		return link
+	}
+
+	parsed.Scheme = strings.ToLower(parsed.Scheme)
+	parsed.Host = strings.ToLower(parsed.Host)
+	parsed.Fragment = ""
+	parsed.RawFragment = ""
+
+	if port := parsed.Port(); (port == "80" && parsed.Scheme == "http") || (port == "443" && parsed.Scheme == "https") {
+		parsed.Host = parsed.Hostname()
+	}
+
+	parsed.Host = strings.TrimPrefix(parsed.Host, "amp.")
+	parsed.Path = canonicalPath(parsed.Path)
+	parsed.RawPath = ""
+
+	query := parsed.Query()
+
+	for name := range query {
+		if shouldStrip(name, DefaultStripParams) || shouldStrip(name, strip) {
+			query.Del(name)
+		}
+	}
+
+	// Encode sorts the parameters by name
+	parsed.RawQuery = query.Encode()
+
+	return parsed.String()
+}
+
+// HashKey is the canonical URL without the differences of scheme and trailing slash,
+// the entries are identified by it.
+func HashKey(link string) string {
+	canonical := CanonicalURL(link)
+
+	parsed, err := url.Parse(canonical)
+	if err != nil || parsed.Host == "" {
+		return canonical
+	}
+
+	if parsed.Scheme == "http" {
+		parsed.Scheme = "https"
+	}
+
+	if parsed.Path != "/" {
+		parsed.Path = strings.TrimSuffix(parsed.Path, "/")
+	}
+
+	return parsed.String()
+}
+
+func canonicalPath(path string) string {
+	switch {
+	case strings.HasSuffix(path, "/amp/"):
+		return strings.TrimSuffix(path, "amp/")
+	case strings.HasSuffix(path, "/amp"):
+		return strings.TrimSuffix(path, "amp")
+	case strings.HasSuffix(path, ".amp.html"):
+		return strings.TrimSuffix(path, ".amp.html") + ".html"
+	case strings.HasPrefix(path, "/amp/"):
+		return strings.TrimPrefix(path, "/amp")
+	}
+
+	return path
+}
+
+func shouldStrip(name string, params []string) bool {
+	name = strings.ToLower(name)
+
+	for _, param := range params {
+		param = strings.ToLower(param)
+
+		if prefix, ok := strings.CutSuffix(param, "*"); ok {
+			if strings.HasPrefix(name, prefix) {
+				return true
+			}
+		} else if name == param {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,47 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vinicius73/gear-feed/pkg/model"
+)
+
+func TestCanonicalURL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input string
+		strip []string
+		want  string
+	}{
+		{input: " https://Foo.com/news/1?utm_source=x&utm_medium=y&id=2#top ", want: "https://foo.com/news/1?id=2"},
+		{input: "https://foo.com/news/1?b=2&a=1&fbclid=x", want: "https://foo.com/news/1?a=1&b=2"},
+		{input: "https://foo.com:443/news/1/amp/", want: "https://foo.com/news/1/"},
+		{input: "https://amp.foo.com/news/1.amp.html", want: "https://foo.com/news/1.html"},
+		{input: "https://foo.com/amp/news/1?amp=1", want: "https://foo.com/news/1"},
+		{input: "https://foo.com/news/1?from=home&page=2", strip: []string{"from"}, want: "https://foo.com/news/1?page=2"},
+		{input: "/news/1", want: "/news/1"},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.want, model.CanonicalURL(test.input, test.strip...), test.input)
+	}
+}
+
+func TestHashKey(t *testing.T) {
+	t.Parallel()
+
+	want := "https://foo.com/news/1"
+
+	for _, input := range []string{
+		"https://foo.com/news/1",
+		"http://foo.com/news/1/",
+		"https://foo.com/news/1/amp",
+		"https://foo.com/news/1?utm_campaign=feed",
+	} {
+		assert.Equal(t, want, model.HashKey(input), input)
+	}
+
+	assert.Equal(t, "https://foo.com/", model.HashKey("http://foo.com/"))
+}
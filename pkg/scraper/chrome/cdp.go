@@ -0,0 +1,113 @@
+package chrome
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+type cdpRequest struct {
+	ID     int    `json:"id"`
+	Method string `json:"method"`
+	Params any    `json:"params,omitempty"`
+}
+
+type cdpResponse struct {
+	ID     int             `json:"id"`
+	Method string          `json:"method"`
+	Result json.RawMessage `json:"result"`
+	Error  *cdpError       `json:"error"`
+}
+
+type cdpError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e cdpError) Error() string {
+	return fmt.Sprintf("devtools error %d: %s", e.Code, e.Message)
+}
+
+type evaluateResult struct {
+	Result struct {
+		Type  string          `json:"type"`
+		Value json.RawMessage `json:"value"`
+	} `json:"result"`
+	ExceptionDetails *struct {
+		Text string `json:"text"`
+	} `json:"exceptionDetails"`
+}
+
+// session sends the DevTools commands of a page, one at a time.
+type session struct {
+	conn *wsConn
+	id   int
+}
+
+func (s *session) call(ctx context.Context, method string, params any, result any) error {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Time{}
+	}
+
+	_ = s.conn.SetDeadline(deadline)
+
+	s.id++
+
+	payload, err := json.Marshal(cdpRequest{ID: s.id, Method: method, Params: params})
+	if err != nil {
+		return err
+	}
+
+	if err = s.conn.WriteText(payload); err != nil {
+		return err
+	}
+
+	for {
+		message, err := s.conn.ReadMessage()
+		if err != nil {
+			return err
+		}
+
+		var response cdpResponse
+
+		if err = json.Unmarshal(message, &response); err != nil {
+			return err
+		}
+
+		// events and late responses are ignored
+		if response.ID != s.id {
+			continue
+		}
+
+		if response.Error != nil {
+			return *response.Error
+		}
+
+		if result == nil {
+			return nil
+		}
+
+		return json.Unmarshal(response.Result, result)
+	}
+}
+
+// evaluate runs the expression in the page and decodes its value.
+func (s *session) evaluate(ctx context.Context, expression string, value any) error {
+	var result evaluateResult
+
+	err := s.call(ctx, "Runtime.evaluate", map[string]any{
+		"expression":    expression,
+		"returnByValue": true,
+	}, &result)
+	if err != nil {
+		return err
+	}
+
+	if result.ExceptionDetails != nil {
+		return fmt.Errorf("%w: %s", ErrEvaluate, result.ExceptionDetails.Text)
+	}
+
+	return json.Unmarshal(result.Result.Value, value)
+}
@@ -0,0 +1,383 @@
+package chrome
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vinicius73/gear-feed/pkg/support"
+)
+
+// Timeout of a render without deadline, it includes the browser launch.
+const Timeout = time.Second * 30
+
+const (
+	pollInterval  = time.Millisecond * 250
+	launchTimeout = time.Second * 15
+)
+
+var (
+	ErrBrowserNotFound = errors.New("chrome or chromium binary not found, set GFEED_CHROME_PATH")
+	ErrLaunch          = errors.New("fail to launch the browser")
+	ErrNavigate        = errors.New("fail to navigate")
+	ErrEvaluate        = errors.New("fail to evaluate the expression")
+	ErrWaitSelector    = errors.New("selector not found in the rendered page")
+)
+
+// Binaries are looked up in the PATH when the browser path is not defined.
+var Binaries = []string{
+	"chromium",
+	"chromium-browser",
+	"google-chrome",
+	"google-chrome-stable",
+	"chrome",
+	"headless-shell",
+}
+
+// Fetcher renders pages with a headless Chromium, driven by the DevTools protocol.
+type Fetcher struct {
+	// Path of the browser binary, it is launched by each Render or Browser.
+	// Launching takes a few seconds, GFEED_CHROME_URL avoids it on frequent runs.
+	Path string
+	// Endpoint of a running browser (e.g. http://127.0.0.1:9222), used instead of launching one.
+	Endpoint string
+}
+
+// Browser renders the pages with the same browser, it is launched on the first render.
+type Browser struct {
+	fetcher  Fetcher
+	mu       sync.Mutex
+	endpoint string
+	stop     func()
+}
+
+type target struct {
+	ID                   string `json:"id"`
+	WebSocketDebuggerURL string `json:"webSocketDebuggerUrl"`
+}
+
+// FromEnv builds the fetcher with GFEED_CHROME_URL and GFEED_CHROME_PATH.
+func FromEnv() Fetcher {
+	return Fetcher{
+		Path:     support.GetEnvString("GFEED_CHROME_PATH", ""),
+		Endpoint: support.GetEnvString("GFEED_CHROME_URL", ""),
+	}
+}
+
+// Render loads the page with a new browser, Browser should be used to render many pages.
+func (f Fetcher) Render(ctx context.Context, page, waitFor, userAgent string) (string, error) {
+	browser := f.Browser()
+
+	defer browser.Close()
+
+	return browser.Render(ctx, page, waitFor, userAgent)
+}
+
+// Browser returns a browser shared by the renders, Close must be called to stop it.
+func (f Fetcher) Browser() *Browser {
+	return &Browser{fetcher: f, mu: sync.Mutex{}, endpoint: "", stop: nil}
+}
+
+// Render loads the page and returns its DOM after the selector is found, or after the load when it is empty.
+func (b *Browser) Render(ctx context.Context, page, waitFor, userAgent string) (string, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, Timeout)
+		defer cancel()
+	}
+
+	endpoint, err := b.start(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return b.fetcher.render(ctx, endpoint, page, waitFor, userAgent)
+}
+
+// Close stops the launched browser, a running browser defined by the endpoint is kept.
+func (b *Browser) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.stop != nil {
+		b.stop()
+	}
+
+	b.endpoint = ""
+	b.stop = nil
+}
+
+func (b *Browser) start(ctx context.Context) (string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.endpoint != "" {
+		return b.endpoint, nil
+	}
+
+	endpoint, stop, err := b.fetcher.start(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	b.endpoint = endpoint
+	b.stop = stop
+
+	return endpoint, nil
+}
+
+func (f Fetcher) render(ctx context.Context, endpoint, page, waitFor, userAgent string) (string, error) {
+	tab, err := newTarget(ctx, endpoint)
+	if err != nil {
+		return "", err
+	}
+
+	defer closeTarget(endpoint, tab.ID)
+
+	conn, err := dialWebsocket(ctx, tab.WebSocketDebuggerURL)
+	if err != nil {
+		return "", err
+	}
+
+	defer conn.Close()
+
+	client := &session{conn: conn, id: 0}
+
+	if userAgent != "" {
+		if err = client.call(ctx, "Network.setUserAgentOverride", map[string]any{"userAgent": userAgent}, nil); err != nil {
+			return "", err
+		}
+	}
+
+	var navigation struct {
+		ErrorText string `json:"errorText"`
+	}
+
+	if err = client.call(ctx, "Page.navigate", map[string]any{"url": page}, &navigation); err != nil {
+		return "", err
+	}
+
+	if navigation.ErrorText != "" {
+		return "", fmt.Errorf("%w to %s: %s", ErrNavigate, page, navigation.ErrorText)
+	}
+
+	if err = waitReady(ctx, client, waitFor); err != nil {
+		return "", err
+	}
+
+	var html string
+
+	if err = client.evaluate(ctx, "document.documentElement.outerHTML", &html); err != nil {
+		return "", err
+	}
+
+	return html, nil
+}
+
+// waitReady polls the page until it is loaded and the selector is found.
+func waitReady(ctx context.Context, client *session, waitFor string) error {
+	selector, err := json.Marshal(waitFor)
+	if err != nil {
+		return err
+	}
+
+	expression := `document.readyState === "complete"`
+
+	if waitFor != "" {
+		expression += " && document.querySelector(" + string(selector) + ") !== null"
+	}
+
+	for {
+		var ready bool
+
+		if err := client.evaluate(ctx, expression, &ready); err != nil {
+			if ctx.Err() != nil {
+				return fmt.Errorf("%w: %s", ErrWaitSelector, waitFor)
+			}
+
+			return err
+		}
+
+		if ready {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: %s", ErrWaitSelector, waitFor)
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+func newTarget(ctx context.Context, endpoint string) (target, error) {
+	var tab target
+
+	// chrome requires PUT to create targets since version 111
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, endpoint+"/json/new?about:blank", nil)
+	if err != nil {
+		return tab, err
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return tab, err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return tab, fmt.Errorf("%w: new target status %d", ErrLaunch, res.StatusCode)
+	}
+
+	err = json.NewDecoder(res.Body).Decode(&tab)
+
+	return tab, err
+}
+
+func closeTarget(endpoint, id string) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+"/json/close/"+url.PathEscape(id), nil)
+	if err != nil {
+		return
+	}
+
+	if res, err := http.DefaultClient.Do(req); err == nil {
+		res.Body.Close()
+	}
+}
+
+// start returns the http endpoint of the browser, launching it when there is no endpoint.
+func (f Fetcher) start(ctx context.Context) (string, func(), error) {
+	if f.Endpoint != "" {
+		return strings.TrimSuffix(f.Endpoint, "/"), func() {}, nil
+	}
+
+	path, err := f.binary()
+	if err != nil {
+		return "", nil, err
+	}
+
+	dataDir, err := os.MkdirTemp("", "gfeed-chrome-")
+	if err != nil {
+		return "", nil, err
+	}
+
+	//nolint:gosec
+	cmd := exec.Command(path,
+		"--headless=new",
+		"--disable-gpu",
+		"--no-sandbox",
+		"--no-first-run",
+		"--no-default-browser-check",
+		"--remote-debugging-address=127.0.0.1",
+		"--remote-debugging-port=0",
+		"--user-data-dir="+dataDir,
+		"about:blank",
+	)
+
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		os.RemoveAll(dataDir)
+
+		return "", nil, err
+	}
+
+	if err = cmd.Start(); err != nil {
+		os.RemoveAll(dataDir)
+
+		return "", nil, fmt.Errorf("%w: %w", ErrLaunch, err)
+	}
+
+	stop := func() {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+
+		os.RemoveAll(dataDir)
+	}
+
+	endpoint, err := readEndpoint(ctx, stderr)
+	if err != nil {
+		stop()
+
+		return "", nil, err
+	}
+
+	return endpoint, stop, nil
+}
+
+func (f Fetcher) binary() (string, error) {
+	if f.Path != "" {
+		return f.Path, nil
+	}
+
+	for _, name := range Binaries {
+		if path, err := exec.LookPath(name); err == nil {
+			return path, nil
+		}
+	}
+
+	return "", ErrBrowserNotFound
+}
+
+// readEndpoint waits for the "DevTools listening on ws://..." line printed by the browser.
+func readEndpoint(ctx context.Context, stderr io.Reader) (string, error) {
+	found := make(chan string, 1)
+	output := &bytes.Buffer{}
+
+	go func() {
+		scanner := bufio.NewScanner(stderr)
+
+		for scanner.Scan() {
+			line := scanner.Text()
+
+			if address, ok := strings.CutPrefix(line, "DevTools listening on "); ok {
+				found <- address
+
+				break
+			}
+
+			output.WriteString(line + "\n")
+		}
+
+		close(found)
+
+		// keep reading, the browser blocks when the pipe is full
+		_, _ = io.Copy(io.Discard, stderr)
+	}()
+
+	timeout := time.NewTimer(launchTimeout)
+	defer timeout.Stop()
+
+	select {
+	case address, ok := <-found:
+		if !ok {
+			return "", fmt.Errorf("%w: %s", ErrLaunch, strings.TrimSpace(output.String()))
+		}
+
+		parsed, err := url.Parse(address)
+		if err != nil {
+			return "", err
+		}
+
+		return "http://" + parsed.Host, nil
+	case <-timeout.C:
+		return "", fmt.Errorf("%w: timeout", ErrLaunch)
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
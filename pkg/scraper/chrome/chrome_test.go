@@ -0,0 +1,246 @@
+package chrome_test
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1" //nolint:gosec
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vinicius73/gear-feed/pkg/scraper/chrome"
+)
+
+// devtools fakes the DevTools endpoints of a running browser.
+type devtools struct {
+	mu          sync.Mutex
+	html        string
+	ready       bool
+	pending     int
+	navigated   string
+	userAgent   string
+	closed      bool
+	expressions []string
+}
+
+func (d *devtools) handler(t *testing.T) http.Handler {
+	t.Helper()
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("PUT /json/new", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"id":                   "T1",
+			"webSocketDebuggerUrl": "ws://" + r.Host + "/devtools/page/T1",
+		})
+	})
+
+	mux.HandleFunc("GET /json/close/T1", func(w http.ResponseWriter, _ *http.Request) {
+		d.mu.Lock()
+		d.closed = true
+		d.mu.Unlock()
+
+		_, _ = w.Write([]byte("Target is closing"))
+	})
+
+	mux.HandleFunc("GET /devtools/page/T1", func(w http.ResponseWriter, r *http.Request) {
+		conn, buf, err := http.NewResponseController(w).Hijack()
+		require.NoError(t, err)
+
+		defer conn.Close()
+
+		hash := sha1.Sum([]byte(r.Header.Get("Sec-Websocket-Key") + "258EAFA5-E914-47DA-95CA-C5AB0DC85B11")) //nolint:gosec
+
+		_, _ = buf.WriteString("HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n" +
+			"Sec-WebSocket-Accept: " + base64.StdEncoding.EncodeToString(hash[:]) + "\r\n\r\n")
+		_ = buf.Flush()
+
+		for {
+			payload, ok := readClientFrame(buf.Reader)
+			if !ok {
+				return
+			}
+
+			var request struct {
+				ID     int            `json:"id"`
+				Method string         `json:"method"`
+				Params map[string]any `json:"params"`
+			}
+
+			require.NoError(t, json.Unmarshal(payload, &request))
+
+			for _, message := range d.answer(request.ID, request.Method, request.Params) {
+				writeServerFrame(buf.Writer, message)
+			}
+		}
+	})
+
+	return mux
+}
+
+func (d *devtools) answer(id int, method string, params map[string]any) []any {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	result := map[string]any{}
+
+	switch method {
+	case "Network.setUserAgentOverride":
+		d.userAgent, _ = params["userAgent"].(string)
+	case "Page.navigate":
+		d.navigated, _ = params["url"].(string)
+		result["frameId"] = "F1"
+
+		// events are sent before the responses
+		return []any{
+			map[string]any{"method": "Page.frameStartedLoading", "params": map[string]any{"frameId": "F1"}},
+			map[string]any{"id": id, "result": result},
+		}
+	case "Runtime.evaluate":
+		expression, _ := params["expression"].(string)
+		d.expressions = append(d.expressions, expression)
+
+		if strings.Contains(expression, "outerHTML") {
+			result["result"] = map[string]any{"type": "string", "value": d.html}
+		} else {
+			// the selector is found after some polls
+			d.pending--
+			result["result"] = map[string]any{"type": "boolean", "value": d.ready && d.pending < 0}
+		}
+	}
+
+	return []any{map[string]any{"id": id, "result": result}}
+}
+
+func readClientFrame(reader *bufio.Reader) ([]byte, bool) {
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(reader, header); err != nil {
+		return nil, false
+	}
+
+	if header[0]&0x0F == 0x8 {
+		return nil, false
+	}
+
+	length := uint64(header[1] & 0x7F)
+
+	switch length {
+	case 126:
+		extended := make([]byte, 2)
+		_, _ = io.ReadFull(reader, extended)
+		length = uint64(binary.BigEndian.Uint16(extended))
+	case 127:
+		extended := make([]byte, 8)
+		_, _ = io.ReadFull(reader, extended)
+		length = binary.BigEndian.Uint64(extended)
+	}
+
+	mask := make([]byte, 4)
+	_, _ = io.ReadFull(reader, mask)
+
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(reader, payload); err != nil {
+		return nil, false
+	}
+
+	for index := range payload {
+		payload[index] ^= mask[index%4]
+	}
+
+	return payload, true
+}
+
+func writeServerFrame(writer *bufio.Writer, message any) {
+	payload, _ := json.Marshal(message)
+
+	header := []byte{0x81}
+
+	switch length := len(payload); {
+	case length < 126:
+		header = append(header, byte(length))
+	case length <= 0xFFFF:
+		header = append(header, 126)
+		header = binary.BigEndian.AppendUint16(header, uint16(length))
+	default:
+		header = append(header, 127)
+		header = binary.BigEndian.AppendUint64(header, uint64(length))
+	}
+
+	_, _ = writer.Write(append(header, payload...))
+	_ = writer.Flush()
+}
+
+func TestFetcherRender(t *testing.T) {
+	t.Parallel()
+
+	// large enough to use the 64 bits frame length
+	html := "<html><body><ul id=\"list\"><li>one</li></ul>" + strings.Repeat(" ", 70000) + "</body></html>"
+	fake := &devtools{html: html, ready: true, pending: 2}
+	server := httptest.NewServer(fake.handler(t))
+
+	defer server.Close()
+
+	fetcher := chrome.Fetcher{Path: "", Endpoint: server.URL}
+
+	rendered, err := fetcher.Render(context.TODO(), "http://example.com/news", "#list li", "gfeed-test")
+	require.NoError(t, err)
+
+	assert.Equal(t, html, rendered)
+	assert.Equal(t, "http://example.com/news", fake.navigated)
+	assert.Equal(t, "gfeed-test", fake.userAgent)
+	assert.True(t, fake.closed)
+	// two polls without the selector, one with it and the outerHTML
+	assert.Len(t, fake.expressions, 4)
+	assert.Contains(t, fake.expressions[0], `document.querySelector("#list li")`)
+}
+
+func TestFetcherRenderWaitTimeout(t *testing.T) {
+	t.Parallel()
+
+	fake := &devtools{html: "", ready: false, pending: 0}
+	server := httptest.NewServer(fake.handler(t))
+
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+	defer cancel()
+
+	_, err := chrome.Fetcher{Path: "", Endpoint: server.URL}.Render(ctx, "http://example.com", ".missing", "")
+	require.ErrorIs(t, err, chrome.ErrWaitSelector)
+	assert.Empty(t, fake.userAgent)
+}
+
+func TestTransport(t *testing.T) {
+	t.Parallel()
+
+	fake := &devtools{html: "<html><body>rendered</body></html>", ready: true, pending: 0}
+	server := httptest.NewServer(fake.handler(t))
+
+	defer server.Close()
+
+	client := http.Client{
+		Transport: chrome.Transport{Browser: chrome.Fetcher{Path: "", Endpoint: server.URL}.Browser(), WaitFor: "body"},
+		Timeout:   time.Second * 5,
+	}
+
+	res, err := client.Get("http://example.com/page")
+	require.NoError(t, err)
+
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "<html><body>rendered</body></html>", string(body))
+	assert.Equal(t, "http://example.com/page", fake.navigated)
+}
@@ -0,0 +1,37 @@
+package chrome
+
+import (
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Transport answers the GET requests with the pages rendered by the browser.
+type Transport struct {
+	Browser *Browser
+	// WaitFor is the CSS selector expected in the rendered page.
+	WaitFor string
+}
+
+func (t Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+
+	html, err := t.Browser.Render(req.Context(), req.URL.String(), t.WaitFor, req.Header.Get("User-Agent"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": {"text/html; charset=utf-8"}},
+		Body:          io.NopCloser(strings.NewReader(html)),
+		ContentLength: int64(len(html)),
+		Request:       req,
+	}, nil
+}
@@ -0,0 +1,249 @@
+package chrome
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/sha1" //nolint:gosec
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// websocketGUID is defined by RFC 6455 to build the accept key.
+const websocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+const (
+	opContinuation = 0x0
+	opText         = 0x1
+	opBinary       = 0x2
+	opClose        = 0x8
+	opPing         = 0x9
+	opPong         = 0xA
+)
+
+var (
+	ErrHandshake   = errors.New("websocket handshake failed")
+	ErrClosed      = errors.New("websocket closed by the peer")
+	ErrFrameLength = errors.New("websocket frame too large")
+)
+
+// maxMessageSize limits the messages read from the browser, a rendered page can be large.
+const maxMessageSize = 64 << 20
+
+// wsConn is a minimal websocket client, enough to talk with the DevTools protocol.
+type wsConn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+func dialWebsocket(ctx context.Context, address string) (*wsConn, error) {
+	parsed, err := url.Parse(address)
+	if err != nil {
+		return nil, err
+	}
+
+	var dialer net.Dialer
+
+	conn, err := dialer.DialContext(ctx, "tcp", parsed.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	nonce := make([]byte, 16) //nolint:gomnd
+	_, _ = rand.Read(nonce)
+	key := base64.StdEncoding.EncodeToString(nonce)
+
+	req := &http.Request{
+		Method:     http.MethodGet,
+		URL:        &url.URL{Scheme: "http", Host: parsed.Host, Path: parsed.Path, RawQuery: parsed.RawQuery},
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header: http.Header{
+			"Upgrade":               {"websocket"},
+			"Connection":            {"Upgrade"},
+			"Sec-Websocket-Key":     {key},
+			"Sec-Websocket-Version": {"13"},
+		},
+		Host: parsed.Host,
+	}
+
+	if err = req.Write(conn); err != nil {
+		conn.Close()
+
+		return nil, err
+	}
+
+	reader := bufio.NewReader(conn)
+
+	res, err := http.ReadResponse(reader, req)
+	if err != nil {
+		conn.Close()
+
+		return nil, err
+	}
+
+	res.Body.Close()
+
+	if res.StatusCode != http.StatusSwitchingProtocols || res.Header.Get("Sec-Websocket-Accept") != acceptKey(key) {
+		conn.Close()
+
+		return nil, ErrHandshake
+	}
+
+	_ = conn.SetDeadline(time.Time{})
+
+	return &wsConn{conn: conn, reader: reader}, nil
+}
+
+func acceptKey(key string) string {
+	hash := sha1.Sum([]byte(key + websocketGUID)) //nolint:gosec
+
+	return base64.StdEncoding.EncodeToString(hash[:])
+}
+
+func (c *wsConn) SetDeadline(deadline time.Time) error {
+	return c.conn.SetDeadline(deadline)
+}
+
+// WriteText sends a masked text frame, as required for clients.
+func (c *wsConn) WriteText(payload []byte) error {
+	return c.writeFrame(opText, payload)
+}
+
+func (c *wsConn) writeFrame(opcode byte, payload []byte) error {
+	header := []byte{0x80 | opcode}
+	length := len(payload)
+
+	switch {
+	case length < 126: //nolint:gomnd
+		header = append(header, 0x80|byte(length))
+	case length <= 0xFFFF:
+		header = append(header, 0x80|126) //nolint:gomnd
+		header = binary.BigEndian.AppendUint16(header, uint16(length))
+	default:
+		header = append(header, 0x80|127) //nolint:gomnd
+		header = binary.BigEndian.AppendUint64(header, uint64(length))
+	}
+
+	mask := make([]byte, 4) //nolint:gomnd
+	_, _ = rand.Read(mask)
+	header = append(header, mask...)
+
+	masked := make([]byte, length)
+
+	for index, char := range payload {
+		masked[index] = char ^ mask[index%4]
+	}
+
+	_, err := c.conn.Write(append(header, masked...))
+
+	return err
+}
+
+// ReadMessage returns the next text or binary message, answering the pings.
+func (c *wsConn) ReadMessage() ([]byte, error) {
+	message := []byte{}
+
+	for {
+		fin, opcode, payload, err := c.readFrame()
+		if err != nil {
+			return nil, err
+		}
+
+		switch opcode {
+		case opPing:
+			if err = c.writeFrame(opPong, payload); err != nil {
+				return nil, err
+			}
+
+			continue
+		case opPong:
+			continue
+		case opClose:
+			return nil, ErrClosed
+		case opText, opBinary, opContinuation:
+			message = append(message, payload...)
+		}
+
+		if len(message) > maxMessageSize {
+			return nil, ErrFrameLength
+		}
+
+		if fin {
+			return message, nil
+		}
+	}
+}
+
+func (c *wsConn) readFrame() (bool, byte, []byte, error) {
+	header := make([]byte, 2) //nolint:gomnd
+
+	if _, err := io.ReadFull(c.reader, header); err != nil {
+		return false, 0, nil, err
+	}
+
+	fin := header[0]&0x80 != 0
+	opcode := header[0] & 0x0F
+	masked := header[1]&0x80 != 0
+	length := uint64(header[1] & 0x7F)
+
+	switch length {
+	case 126: //nolint:gomnd
+		extended := make([]byte, 2) //nolint:gomnd
+		if _, err := io.ReadFull(c.reader, extended); err != nil {
+			return false, 0, nil, err
+		}
+
+		length = uint64(binary.BigEndian.Uint16(extended))
+	case 127: //nolint:gomnd
+		extended := make([]byte, 8) //nolint:gomnd
+		if _, err := io.ReadFull(c.reader, extended); err != nil {
+			return false, 0, nil, err
+		}
+
+		length = binary.BigEndian.Uint64(extended)
+	}
+
+	if length > maxMessageSize {
+		return false, 0, nil, ErrFrameLength
+	}
+
+	mask := make([]byte, 4) //nolint:gomnd
+
+	if masked {
+		if _, err := io.ReadFull(c.reader, mask); err != nil {
+			return false, 0, nil, err
+		}
+	}
+
+	payload := make([]byte, length)
+
+	if _, err := io.ReadFull(c.reader, payload); err != nil {
+		return false, 0, nil, err
+	}
+
+	if masked {
+		for index := range payload {
+			payload[index] ^= mask[index%4]
+		}
+	}
+
+	return fin, opcode, payload, nil
+}
+
+func (c *wsConn) Close() error {
+	_ = c.writeFrame(opClose, []byte{0x03, 0xE8}) //nolint:gomnd // normal closure
+
+	return c.conn.Close()
+}
@@ -0,0 +1,76 @@
+package scraper_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vinicius73/gear-feed/pkg/model"
+	"github.com/vinicius73/gear-feed/pkg/scraper"
+	"github.com/vinicius73/gear-feed/pkg/scraper/chrome"
+	"github.com/vinicius73/gear-feed/pkg/scraper/testdata"
+)
+
+func hasBrowser() bool {
+	if os.Getenv("GFEED_CHROME_PATH") != "" || os.Getenv("GFEED_CHROME_URL") != "" {
+		return true
+	}
+
+	for _, name := range chrome.Binaries {
+		if _, err := exec.LookPath(name); err == nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+func TestFindEntriesChrome(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(testdata.FileHandler())
+	defer server.Close()
+
+	source, err := testdata.ParseSource(server.URL, `
+name: test_chrome
+enabled: true
+fetcher: chrome
+paths:
+ - /example_10.html
+attributes:
+	entry_selector: "#news > article"
+	link:
+		path: "h2 a"
+		attribute: "href"
+	title:
+		path: "h2 a"
+	`)
+	require.NoError(t, err)
+
+	// without the browser the page has no entries
+	source.Fetcher = scraper.FetcherHTTP
+
+	entries, err := scraper.FindEntries[model.Entry](scraper.WithoutDelay(context.TODO()), source)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	if !hasBrowser() {
+		t.Skip("chrome is not available")
+	}
+
+	source.Fetcher = scraper.FetcherChrome
+
+	entries, err = scraper.FindEntries[model.Entry](scraper.WithoutDelay(context.TODO()), source)
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+
+	for index, entry := range entries {
+		assert.Equal(t, "Rendered news "+strconv.Itoa(index+1), entry.Title)
+		assert.Equal(t, server.URL+"/news/rendered-"+strconv.Itoa(index+1), entry.URL)
+	}
+}
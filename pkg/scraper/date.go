@@ -0,0 +1,86 @@
+package scraper
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DateFormatUnix      = "unix"
+	DateFormatUnixMilli = "unix_ms"
+)
+
+// dateLayouts are tried in order when PathFinderDate.Format is empty.
+var dateLayouts = []string{
+	time.RFC3339Nano,
+	time.RFC3339,
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC822Z,
+	time.RFC822,
+	time.RFC850,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+type PathFinderDate struct {
+	PathFinder `yaml:"path_finder"`
+	Format     string `yaml:"format"`
+}
+
+func (date PathFinderDate) findDate(el Element) time.Time {
+	if date.isEmpty() {
+		return time.Time{}
+	}
+
+	return date.parse(date.findAttribute(el))
+}
+
+func (date PathFinderDate) parse(value string) time.Time {
+	value = strings.TrimSpace(value)
+
+	if value == "" {
+		return time.Time{}
+	}
+
+	switch date.Format {
+	case "":
+		return parseDateAuto(value)
+	case DateFormatUnix, DateFormatUnixMilli:
+		return parseDateUnix(value, date.Format)
+	}
+
+	parsed, err := time.Parse(date.Format, value)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return parsed
+}
+
+func parseDateAuto(value string) time.Time {
+	for _, layout := range dateLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed
+		}
+	}
+
+	return time.Time{}
+}
+
+func parseDateUnix(value, format string) time.Time {
+	num, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+
+	if format == DateFormatUnixMilli {
+		return time.UnixMilli(num)
+	}
+
+	return time.Unix(num, 0)
+}
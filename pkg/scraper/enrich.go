@@ -0,0 +1,199 @@
+package scraper
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/vinicius73/gear-feed/pkg/model"
+	"github.com/vinicius73/gear-feed/pkg/stories/fetcher"
+)
+
+const (
+	defaultEnrichConcurrency = 4
+	articleCacheTTL          = time.Hour * 6
+)
+
+// Enrich fetches the article of each entry to fill the data missing in the listing page.
+type Enrich struct {
+	Enabled bool `yaml:"enabled"`
+	// Concurrency of the article requests, 4 by default.
+	Concurrency int `yaml:"concurrency,omitempty"`
+}
+
+func (e Enrich) concurrency() int {
+	if e.Concurrency <= 0 {
+		return defaultEnrichConcurrency
+	}
+
+	return e.Concurrency
+}
+
+// articles is shared by all sources, the same article is not fetched twice.
+var articles = &articleCache{
+	mu:    sync.Mutex{},
+	items: map[string]*article{},
+	ttl:   articleCacheTTL,
+}
+
+type article struct {
+	done   chan struct{}
+	result fetcher.Result
+	err    error
+	// expires is zero while the article is loading.
+	expires time.Time
+}
+
+type articleCache struct {
+	mu    sync.Mutex
+	items map[string]*article
+	ttl   time.Duration
+}
+
+func (c *articleCache) fetch(ctx context.Context, link string, transport http.RoundTripper) (fetcher.Result, error) {
+	c.mu.Lock()
+
+	now := time.Now()
+	item, found := c.items[link]
+
+	if found && (item.expires.IsZero() || item.expires.After(now)) {
+		c.mu.Unlock()
+
+		select {
+		case <-item.done:
+			return item.result, item.err
+		case <-ctx.Done():
+			return fetcher.Result{}, ctx.Err()
+		}
+	}
+
+	c.evict(now)
+
+	item = &article{done: make(chan struct{}), result: fetcher.Result{}, err: nil, expires: time.Time{}}
+	c.items[link] = item
+	c.mu.Unlock()
+
+	item.result, item.err = fetcher.Fetch(ctx, fetcher.Options{
+		SourceURL:     link,
+		DefaultWidth:  0,
+		DefaultHeight: 0,
+		Transport:     transport,
+	})
+
+	c.mu.Lock()
+	// a canceled request can be retried
+	if item.err != nil && ctx.Err() != nil {
+		delete(c.items, link)
+	} else {
+		item.expires = time.Now().Add(c.ttl)
+	}
+	c.mu.Unlock()
+
+	close(item.done)
+
+	return item.result, item.err
+}
+
+func (c *articleCache) evict(now time.Time) {
+	for link, item := range c.items {
+		if !item.expires.IsZero() && !item.expires.After(now) {
+			delete(c.items, link)
+		}
+	}
+}
+
+// enrich fills the entries with the article data, keeping the order of the entries.
+func enrich[T model.IEntry](ctx context.Context, source SourceDefinition, entries []T) []T {
+	logger := zerolog.Ctx(ctx)
+	// the articles are plain pages, the chrome fetcher is only used by the listing
+	transport, _ := ctx.Value(transportKey{}).(http.RoundTripper)
+	slots := make(chan struct{}, source.Enrich.concurrency())
+	wg := sync.WaitGroup{}
+
+	for index, entry := range entries {
+		current, ok := any(entry).(model.Entry)
+		if !ok || current.URL == "" {
+			continue
+		}
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			result, err := articles.fetch(ctx, current.URL, transport)
+			if err != nil {
+				logger.Warn().Err(err).Str("url", current.URL).Msg("Fail to enrich entry")
+
+				return
+			}
+
+			//nolint:forcetypeassert
+			entries[index] = any(fillEntry(source, current, result)).(T)
+		}()
+	}
+
+	wg.Wait()
+
+	return entries
+}
+
+// fillEntry keeps the listing data, except for the truncated titles and the canonical link.
+func fillEntry(source SourceDefinition, entry model.Entry, result fetcher.Result) model.Entry {
+	if canonical := model.CanonicalURL(result.Canonical, source.StripParams...); sameArticle(entry.URL, canonical) {
+		entry.URL = canonical
+	}
+
+	if result.Title != "" && (entry.Title == "" || len(entry.Title) >= titleLimit ||
+		(len(result.Title) > len(entry.Title) && strings.HasPrefix(result.Title, entry.Title))) {
+		entry.Title = result.Title
+	}
+
+	if entry.Image == "" {
+		entry.Image = result.ImageURL
+	}
+
+	if entry.Description == "" {
+		entry.Description = result.Text
+	}
+
+	if entry.SiteName == "" {
+		entry.SiteName = result.SiteName
+	}
+
+	if entry.PublishedAt.IsZero() {
+		entry.PublishedAt = result.PublishedAt
+	}
+
+	return entry
+}
+
+// sameArticle ignores canonical links of other sites, or of the home page.
+func sameArticle(link, canonical string) bool {
+	if canonical == "" {
+		return false
+	}
+
+	current, err := url.Parse(link)
+	if err != nil {
+		return false
+	}
+
+	parsed, err := url.Parse(canonical)
+	if err != nil || strings.Trim(parsed.Path, "/") == "" {
+		return false
+	}
+
+	host := func(value *url.URL) string {
+		return strings.TrimPrefix(strings.TrimPrefix(value.Hostname(), "www."), "amp.")
+	}
+
+	return host(current) == host(parsed)
+}
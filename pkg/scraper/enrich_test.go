@@ -0,0 +1,110 @@
+package scraper_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vinicius73/gear-feed/pkg/model"
+	"github.com/vinicius73/gear-feed/pkg/scraper"
+	"github.com/vinicius73/gear-feed/pkg/scraper/testdata"
+)
+
+func TestEnrichEntries(t *testing.T) {
+	t.Parallel()
+
+	log := &requestLog{mu: sync.Mutex{}, paths: []string{}}
+	server := log.server(t)
+
+	source, err := testdata.ParseSource(server.URL, `
+name: enrich
+paths:
+ - /enrich_01.html
+enrich:
+	enabled: true
+	concurrency: 2
+attributes:
+	entry_selector: "#news > article"
+	link:
+		path: "h2 a"
+		attribute: "href"
+	title:
+		path: "h2 a"
+	image:
+		path: "img"
+		attribute: "src"
+	published:
+		path_finder:
+			path: "time"
+			attribute: "datetime"
+	`)
+	require.NoError(t, err)
+
+	ctx := scraper.WithoutDelay(context.TODO())
+
+	entries, err := scraper.FindEntries[model.Entry](ctx, source)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	// the truncated title is replaced, the missing data is filled
+	assert.Equal(t, server.URL+"/news/first-article", entries[0].URL)
+	assert.Equal(t, "The first article with a long title", entries[0].Title)
+	assert.Equal(t, server.URL+"/images/article-01.jpg", entries[0].Image)
+	assert.Equal(t, "The description of the first article.", entries[0].Description)
+	assert.Equal(t, "Example News", entries[0].SiteName)
+	assert.Equal(t, time.Date(2024, 9, 2, 8, 30, 0, 0, time.UTC), entries[0].PublishedAt.UTC())
+
+	// the listing data is kept, the canonical link of other site is ignored
+	assert.Equal(t, server.URL+"/article_02.html", entries[1].URL)
+	assert.Equal(t, "Second article", entries[1].Title)
+	assert.Equal(t, server.URL+"/listing.jpg", entries[1].Image)
+	assert.Equal(t, "The description of the second article.", entries[1].Description)
+	assert.Empty(t, entries[1].SiteName)
+	assert.Equal(t, time.Date(2024, 9, 1, 10, 0, 0, 0, time.UTC), entries[1].PublishedAt.UTC())
+
+	_, err = scraper.FindEntries[model.Entry](ctx, source)
+	require.NoError(t, err)
+
+	// the articles are cached
+	assert.ElementsMatch(t, []string{
+		"/enrich_01.html", "/article_01.html", "/article_02.html", "/enrich_01.html",
+	}, log.paths)
+}
+
+func TestEnrichKeepsEntriesOnFailure(t *testing.T) {
+	t.Parallel()
+
+	log := &requestLog{mu: sync.Mutex{}, paths: []string{}}
+	server := log.server(t)
+
+	source, err := testdata.ParseSource(server.URL, `
+name: enrich_missing
+paths:
+ - /enrich_01.html
+enrich:
+	enabled: true
+attributes:
+	entry_selector: "#news > article"
+	link:
+		path: "h2 a"
+		attribute: "href"
+	title:
+		path: "h2 a"
+	`)
+	require.NoError(t, err)
+
+	// the articles are not found
+	source.Paths = []string{server.URL + "/enrich_01.html"}
+	source.BaseURL = server.URL + "/missing"
+
+	entries, err := scraper.FindEntries[model.Entry](scraper.WithoutDelay(context.TODO()), source)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	assert.Equal(t, "The first article with a long", entries[0].Title)
+	assert.Empty(t, entries[0].Description)
+	assert.Contains(t, log.paths, "/missing/article_01.html")
+}
@@ -0,0 +1,147 @@
+package scraper
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/vinicius73/gear-feed/pkg/model"
+)
+
+var reImageSrc = regexp.MustCompile(`<img[^>]+src=["']([^"']+)["']`)
+
+var rssAttributes = AttributesFinder{
+	EntrySelector: "//channel/item",
+	Category: PathFinderCategory{
+		PathFinder: PathFinder{Path: "/category"},
+	},
+	Link:  PathFinder{Path: "/link"},
+	Title: PathFinder{Path: "/title"},
+}
+
+var atomAttributes = AttributesFinder{
+	EntrySelector: "//feed/entry",
+	Category: PathFinderCategory{
+		PathFinder: PathFinder{Path: "/category", Attribute: "term"},
+	},
+	Link:  PathFinder{Path: "/link[not(@rel) or @rel='alternate']", Attribute: "href"},
+	Title: PathFinder{Path: "/title"},
+}
+
+// feedImages are tried in order when the source does not define an image finder.
+var feedImages = map[string][]PathFinder{
+	RSS: {
+		{Path: "/enclosure[starts-with(@type,'image')]", Attribute: "url"},
+		{Path: "/media:content[not(@medium) or @medium='image']", Attribute: "url"},
+		{Path: "/media:thumbnail", Attribute: "url"},
+		{Path: "/media:group/media:content", Attribute: "url"},
+		{Path: "/media:group/media:thumbnail", Attribute: "url"},
+	},
+	ATOM: {
+		{Path: "/link[@rel='enclosure'][starts-with(@type,'image')]", Attribute: "href"},
+		{Path: "/media:content[not(@medium) or @medium='image']", Attribute: "url"},
+		{Path: "/media:thumbnail", Attribute: "url"},
+		{Path: "/media:group/media:thumbnail", Attribute: "url"},
+	},
+}
+
+// feedDates are tried in order when the source does not define a published finder.
+var feedDates = map[string][]PathFinderDate{
+	RSS: {
+		{PathFinder: PathFinder{Path: "/pubDate"}},
+		{PathFinder: PathFinder{Path: "/dc:date"}},
+	},
+	ATOM: {
+		{PathFinder: PathFinder{Path: "/published"}},
+		{PathFinder: PathFinder{Path: "/updated"}},
+	},
+}
+
+// feedDescriptions are used as last resort to find an image inside the entry HTML.
+var feedDescriptions = map[string][]string{
+	RSS:  {"/content:encoded", "/description"},
+	ATOM: {"/content", "/summary"},
+}
+
+// FindEntriesFeed load entries from RSS or Atom feeds.
+// The source attributes are optional and override the feed defaults.
+func FindEntriesFeed[T model.IEntry](ctx context.Context, source SourceDefinition) ([]T, error) {
+	source.Attributes = source.feedAttributes()
+
+	return FindEntriesXHTML[T](ctx, source)
+}
+
+func isFeedParser(parser string) bool {
+	parser = strings.ToUpper(parser)
+
+	return parser == RSS || parser == ATOM
+}
+
+func (d SourceDefinition) feedAttributes() AttributesFinder {
+	defaults := rssAttributes
+
+	if strings.ToUpper(d.Parser) == ATOM {
+		defaults = atomAttributes
+	}
+
+	custom := d.Attributes
+
+	if custom.EntrySelector != "" {
+		defaults.EntrySelector = custom.EntrySelector
+	}
+
+	if !custom.Category.isEmpty() {
+		defaults.Category.PathFinder = custom.Category.PathFinder
+	}
+
+	defaults.Category.Alloweds = custom.Category.Alloweds
+	defaults.Link = defaults.Link.override(custom.Link)
+	defaults.Title = defaults.Title.override(custom.Title)
+	defaults.Image = defaults.Image.override(custom.Image)
+	defaults.Published = custom.Published
+
+	return defaults
+}
+
+func (option PathFinder) isEmpty() bool {
+	return option.Path == "" && option.Attribute == ""
+}
+
+func (option PathFinder) override(custom PathFinder) PathFinder {
+	if custom.isEmpty() {
+		return option
+	}
+
+	return custom
+}
+
+func findFeedImage(parser string, el Element) string {
+	parser = strings.ToUpper(parser)
+
+	for _, finder := range feedImages[parser] {
+		if image := finder.findAttribute(el); image != "" {
+			return image
+		}
+	}
+
+	for _, path := range feedDescriptions[parser] {
+		match := reImageSrc.FindStringSubmatch(el.ChildText(path))
+
+		if len(match) > 1 {
+			return match[1]
+		}
+	}
+
+	return ""
+}
+
+func findFeedPublished(parser string, el Element) time.Time {
+	for _, finder := range feedDates[strings.ToUpper(parser)] {
+		if published := finder.findDate(el); !published.IsZero() {
+			return published
+		}
+	}
+
+	return time.Time{}
+}
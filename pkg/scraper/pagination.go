@@ -0,0 +1,121 @@
+package scraper
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/tidwall/gjson"
+)
+
+const defaultMaxPages = 5
+
+// Pagination follows the next pages of each path, until the limit is satisfied.
+type Pagination struct {
+	// Next is the selector of the next page link, a gjson path for the JSON parser.
+	Next string `yaml:"next,omitempty"`
+	// Attribute of the next link element, "href" by default.
+	Attribute string `yaml:"attribute,omitempty"`
+	// Template builds the next page URL, replacing {path}, {page} and {cursor}.
+	Template string `yaml:"template,omitempty"`
+	// Cursor is the gjson path of the cursor used by the template, only for the JSON parser.
+	Cursor string `yaml:"cursor,omitempty"`
+	// MaxPages includes the first page, defaults to 5 when the pagination is defined.
+	MaxPages int `yaml:"max_pages,omitempty"`
+}
+
+// page is the state of the visited page, used to find the next one.
+type page struct {
+	path   string
+	number int
+	// next is the link or cursor found in the page.
+	next    string
+	entries int
+}
+
+func (p Pagination) Enabled() bool {
+	return p.Next != "" || p.Template != "" || p.Cursor != ""
+}
+
+func (p Pagination) maxPages() int {
+	if !p.Enabled() {
+		return 1
+	}
+
+	if p.MaxPages <= 0 {
+		return defaultMaxPages
+	}
+
+	return p.MaxPages
+}
+
+func (p Pagination) attribute() string {
+	if p.Attribute == "" {
+		return "href"
+	}
+
+	return p.Attribute
+}
+
+// nextPage returns the URL of the page after the current one, and moves to it.
+func (d SourceDefinition) nextPage(current *page) string {
+	next := d.nextURL(*current)
+	current.number++
+
+	return next
+}
+
+// nextURL returns an empty string when there are no more pages.
+func (d SourceDefinition) nextURL(current page) string {
+	pagination := d.Pagination
+
+	if current.number >= pagination.maxPages() {
+		return ""
+	}
+
+	switch {
+	case pagination.Cursor != "":
+		if current.next == "" {
+			return ""
+		}
+
+		return d.absouteURL(pagination.expand(current.path, current.number+1, url.QueryEscape(current.next)))
+	case pagination.Next != "":
+		if current.next == "" {
+			return ""
+		}
+
+		return d.absouteURL(current.next)
+	case pagination.Template != "":
+		// a page without entries is the last one
+		if current.entries == 0 {
+			return ""
+		}
+
+		return d.absouteURL(pagination.expand(current.path, current.number+1, ""))
+	}
+
+	return ""
+}
+
+func (p Pagination) expand(path string, number int, cursor string) string {
+	return strings.NewReplacer(
+		"{path}", path,
+		"{page}", strconv.Itoa(number),
+		"{cursor}", cursor,
+	).Replace(p.Template)
+}
+
+// findNextJSON returns the cursor, or the next page link, of a JSON page.
+func (p Pagination) findNextJSON(body []byte) string {
+	path := p.Next
+	if p.Cursor != "" {
+		path = p.Cursor
+	}
+
+	if path == "" {
+		return ""
+	}
+
+	return gjson.GetBytes(body, path).String()
+}
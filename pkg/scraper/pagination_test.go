@@ -0,0 +1,171 @@
+package scraper_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vinicius73/gear-feed/pkg/model"
+	"github.com/vinicius73/gear-feed/pkg/scraper"
+	"github.com/vinicius73/gear-feed/pkg/scraper/testdata"
+)
+
+type requestLog struct {
+	mu    sync.Mutex
+	paths []string
+}
+
+func (l *requestLog) server(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	handler := testdata.FileHandler()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		l.mu.Lock()
+		l.paths = append(l.paths, r.URL.Path)
+		l.mu.Unlock()
+
+		handler.ServeHTTP(w, r)
+	}))
+
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func findPaged(t *testing.T, input string) ([]string, []string) {
+	t.Helper()
+
+	log := &requestLog{mu: sync.Mutex{}, paths: []string{}}
+	server := log.server(t)
+
+	source, err := testdata.ParseSource(server.URL, input)
+	require.NoError(t, err)
+
+	entries, err := scraper.FindEntries[model.Entry](scraper.WithoutDelay(context.TODO()), source)
+	require.NoError(t, err)
+
+	titles := make([]string, len(entries))
+
+	for index, entry := range entries {
+		titles[index] = entry.Title
+	}
+
+	return titles, log.paths
+}
+
+func TestPaginationNextLink(t *testing.T) {
+	t.Parallel()
+
+	titles, paths := findPaged(t, `
+name: paged
+paths:
+ - /paged_01.html
+pagination:
+	next: "nav a[rel=next]"
+attributes:
+	entry_selector: "#news > article"
+	link:
+		path: "h2 a"
+		attribute: "href"
+	title:
+		path: "h2 a"
+	`)
+
+	assert.Equal(t, []string{"Paged news 1", "Paged news 2", "Paged news 3", "Paged news 4", "Paged news 5"}, titles)
+	assert.Equal(t, []string{"/paged_01.html", "/paged_02.html", "/paged_03.html"}, paths)
+}
+
+func TestPaginationStopsOnLimit(t *testing.T) {
+	t.Parallel()
+
+	titles, paths := findPaged(t, `
+name: paged
+limit: 3
+paths:
+ - /paged_01.html
+pagination:
+	next: "nav a[rel=next]"
+attributes:
+	entry_selector: "#news > article"
+	link:
+		path: "h2 a"
+		attribute: "href"
+	title:
+		path: "h2 a"
+	`)
+
+	assert.Equal(t, []string{"Paged news 1", "Paged news 2", "Paged news 3"}, titles)
+	assert.Equal(t, []string{"/paged_01.html", "/paged_02.html"}, paths)
+}
+
+func TestPaginationMaxPages(t *testing.T) {
+	t.Parallel()
+
+	titles, paths := findPaged(t, `
+name: paged
+paths:
+ - /paged_01.html
+pagination:
+	template: "/paged_0{page}.html"
+	max_pages: 2
+attributes:
+	entry_selector: "#news > article"
+	link:
+		path: "h2 a"
+		attribute: "href"
+	title:
+		path: "h2 a"
+	`)
+
+	assert.Len(t, titles, 4)
+	assert.Equal(t, []string{"/paged_01.html", "/paged_02.html"}, paths)
+}
+
+func TestPaginationTemplateEndsOnMissingPage(t *testing.T) {
+	t.Parallel()
+
+	titles, paths := findPaged(t, `
+name: paged
+paths:
+ - /paged_01.html
+pagination:
+	template: "/paged_0{page}.html"
+attributes:
+	entry_selector: "#news > article"
+	link:
+		path: "h2 a"
+		attribute: "href"
+	title:
+		path: "h2 a"
+	`)
+
+	assert.Len(t, titles, 5)
+	assert.Equal(t, []string{"/paged_01.html", "/paged_02.html", "/paged_03.html", "/paged_04.html"}, paths)
+}
+
+func TestPaginationJSONCursor(t *testing.T) {
+	t.Parallel()
+
+	titles, paths := findPaged(t, `
+name: paged_json
+parser: JSON
+paths:
+ - /paged_01.json
+pagination:
+	cursor: meta.next
+	template: "/paged_{cursor}.json"
+attributes:
+	entry_selector: items
+	link:
+		path: url
+	title:
+		path: title
+	`)
+
+	assert.Equal(t, []string{"Json news 1", "Json news 2", "Json news 3"}, titles)
+	assert.Equal(t, []string{"/paged_01.json", "/paged_cursor-2.json"}, paths)
+}
@@ -16,12 +16,14 @@ import (
 	"github.com/gocolly/colly"
 	"github.com/rs/zerolog"
 	"github.com/tidwall/gjson"
+	"github.com/vinicius73/gear-feed/pkg/filters"
 	"github.com/vinicius73/gear-feed/pkg/model"
 	"github.com/vinicius73/gear-feed/pkg/support"
 )
 
 var (
 	ErrCategoryNotAllowed  = errors.New("category not allowed")
+	ErrEntryFiltered       = errors.New("entry filtered")
 	ErrFailToCrateRequest  = errors.New("fail to create request")
 	ErrCloudflareChallenge = errors.New("cloudflare challenge detected")
 )
@@ -76,7 +78,8 @@ func getBrowserHeaders() http.Header {
 	}
 }
 
-func newCollector(ctx context.Context) *colly.Collector {
+// newCollector returns the collector and the func that releases its transport.
+func newCollector(ctx context.Context, source SourceDefinition) (*colly.Collector, func()) {
 	c := colly.NewCollector(
 		colly.UserAgent(getRandomUserAgent()),
 		colly.MaxDepth(1),
@@ -85,11 +88,17 @@ func newCollector(ctx context.Context) *colly.Collector {
 		// colly.CacheDir(_tmpDir),
 		colly.AllowURLRevisit(),
 	)
-	c.SetRequestTimeout(requestTimeout)
+	c.SetRequestTimeout(requestTimeoutFor(source))
+
+	transport, closeTransport := transportFor(ctx, source)
+
+	if transport != nil {
+		c.WithTransport(transport)
+	}
 	// logger := zerolog.Ctx(ctx)
 	// logger.Debug().Str("tmpDir", _tmpDir).Msg("colly.NewCollector: Using temporary directory")
 
-	return c
+	return c, closeTransport
 }
 
 // isCloudflareChallenge verifica se a resposta é um desafio da Cloudflare.
@@ -101,9 +110,20 @@ func isCloudflareChallenge(body []byte) bool {
 }
 
 func FindEntries[T model.IEntry](ctx context.Context, source SourceDefinition) ([]T, error) {
+	entries, err := findEntries[T](ctx, source)
+	if err != nil || !source.Enrich.Enabled {
+		return entries, err
+	}
+
+	return enrich(ctx, source, entries), nil
+}
+
+func findEntries[T model.IEntry](ctx context.Context, source SourceDefinition) ([]T, error) {
 	switch strings.ToUpper(source.Parser) {
-	case "JSON":
+	case JSON:
 		return FindEntriesJSON[T](ctx, source)
+	case RSS, ATOM:
+		return FindEntriesFeed[T](ctx, source)
 	default:
 		return FindEntriesXHTML[T](ctx, source)
 	}
@@ -111,14 +131,21 @@ func FindEntries[T model.IEntry](ctx context.Context, source SourceDefinition) (
 
 func FindEntriesJSON[T model.IEntry](ctx context.Context, source SourceDefinition) ([]T, error) {
 	logger := zerolog.Ctx(ctx).With().Str("source", source.Name).Logger()
-	httpClient := http.Client{Timeout: requestTimeout}
-	urls := source.urls()
+	transport, closeTransport := transportFor(ctx, source)
+
+	defer closeTransport()
+
+	httpClient := http.Client{Timeout: requestTimeoutFor(source), Transport: transport}
+	paths := append([]string{}, source.Paths...)
 	limit := source.Limit
+	if limit == 0 {
+		limit = math.MaxInt
+	}
 	entries := []T{}
 
-	randGen.Shuffle(len(urls), func(i, j int) { urls[i], urls[j] = urls[j], urls[i] })
+	shufflePaths(ctx, paths)
 
-	doRequest := func(url string) error {
+	doRequest := func(url string, current *page) error {
 		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 		if err != nil {
 			return ErrFailToCrateRequest
@@ -146,15 +173,24 @@ func FindEntriesJSON[T model.IEntry](ctx context.Context, source SourceDefinitio
 		}
 
 		resultEntries := gjson.GetBytes(body, source.Attributes.EntrySelector).Array()
+		current.entries = len(resultEntries)
+		current.next = source.Pagination.findNextJSON(body)
+
 		for _, row := range resultEntries {
 			if limit == 0 {
 				break
 			}
-			limit--
 			title := row.Get(source.Attributes.Title.Path).String()
 			link := row.Get(source.Attributes.Link.Path).String()
 			image := row.Get(source.Attributes.Image.Path).String()
-			entry := source.buildEntry(title, link, image, []string{}).(T)
+			published := source.Attributes.Published.parse(row.Get(source.Attributes.Published.Path).String())
+			entry := source.buildEntry(title, link, image, []string{}, published).(T)
+			if !source.Filters.Allow(filters.FromEntry(entry)) {
+				logger.Debug().Str("title", title).Msg(ErrEntryFiltered.Error())
+
+				continue
+			}
+			limit--
 			entries = append(entries, entry)
 			if limit == 0 {
 				logger.Warn().Msgf("Limit reached (%v)", source.Limit)
@@ -164,23 +200,38 @@ func FindEntriesJSON[T model.IEntry](ctx context.Context, source SourceDefinitio
 		return nil
 	}
 
-	for _, url := range urls {
-		logger.Info().Msgf("Visiting %s", url)
-		for attempt := 0; attempt <= maxRetries; attempt++ {
-			err := doRequest(url)
-			if err == nil {
-				break
+	for _, path := range paths {
+		visited := map[string]bool{}
+		current := page{path: path, number: 1, next: "", entries: 0}
+
+		for url := source.absouteURL(path); url != "" && limit > 0 && !visited[url]; url = source.nextPage(&current) {
+			visited[url] = true
+			current.next = ""
+			current.entries = 0
+
+			logger.Info().Msgf("Visiting %s", url)
+			var err error
+			for attempt := 0; attempt <= maxRetries; attempt++ {
+				err = doRequest(url, &current)
+				if err == nil {
+					break
+				}
+				if errors.Is(err, ErrCloudflareChallenge) {
+					logger.Warn().Msgf("Cloudflare challenge detected, retrying (%d/%d)", attempt+1, maxRetries)
+					sleep(ctx, time.Duration(randGen.Intn(5)+5)*time.Second) // Atraso de 5-10 segundos
+				} else {
+					logger.Error().Err(err).Msgf("Fail to visit %s", url)
+
+					return nil, err
+				}
 			}
-			if errors.Is(err, ErrCloudflareChallenge) {
-				logger.Warn().Msgf("Cloudflare challenge detected, retrying (%d/%d)", attempt+1, maxRetries)
-				time.Sleep(time.Duration(randGen.Intn(5)+5) * time.Second) // Atraso de 5-10 segundos
-			} else {
-				logger.Error().Err(err).Msgf("Fail to visit %s", url)
+			if err != nil {
+				logger.Error().Msgf("Failed to bypass Cloudflare after %d retries", maxRetries)
 
-				return nil, err
+				return nil, fmt.Errorf("%w: %s", err, url)
 			}
+			sleep(ctx, time.Duration(randGen.Intn(5))*time.Second) // Atraso após cada requisição
 		}
-		time.Sleep(time.Duration(randGen.Intn(5)) * time.Second) // Atraso após cada requisição
 	}
 
 	return entries, nil
@@ -201,7 +252,7 @@ func FindEntriesXHTML[T model.IEntry](ctx context.Context, source SourceDefiniti
 		}
 		entry, err := onEntry[T](ctx, source, e)
 		if err != nil {
-			if !errors.Is(err, ErrCategoryNotAllowed) {
+			if !errors.Is(err, ErrCategoryNotAllowed) && !errors.Is(err, ErrEntryFiltered) {
 				logger.Error().Err(err).Msg("Error on entry")
 			}
 
@@ -216,7 +267,7 @@ func FindEntriesXHTML[T model.IEntry](ctx context.Context, source SourceDefiniti
 	}
 
 	startTime := time.Now()
-	err := visit(ctx, source, callback)
+	err := visit(ctx, source, callback, func() bool { return limit == 0 })
 	if err != nil {
 		return nil, err
 	}
@@ -226,21 +277,52 @@ func FindEntriesXHTML[T model.IEntry](ctx context.Context, source SourceDefiniti
 	return entries, nil
 }
 
-func visit(ctx context.Context, source SourceDefinition, callback func(e Element)) error {
+// visit follows the pagination of each path until satisfied returns true.
+func visit(ctx context.Context, source SourceDefinition, callback func(e Element), satisfied func() bool) error {
 	logger := zerolog.Ctx(ctx)
-	collector := newCollector(ctx)
+	collector, closeTransport := newCollector(ctx, source)
+
+	// the pages, including the pagination, are rendered by the same browser
+	defer closeTransport()
+
 	collector.Async = false // Síncrono para facilitar re-tentativas
 	entrySelector := source.Attributes.EntrySelector
 	parser := strings.ToUpper(source.Parser)
+	pagination := source.Pagination
+	current := page{path: "", number: 1, next: "", entries: 0}
 
-	if parser == "XML" {
+	onNext := func(request *colly.Request, value string) {
+		if current.next == "" && value != "" {
+			current.next = request.AbsoluteURL(value)
+		}
+	}
+
+	if parser == XML || isFeedParser(parser) {
 		collector.OnXML(entrySelector, func(e *colly.XMLElement) {
+			current.entries++
 			callback(e)
 		})
+
+		if pagination.Next != "" {
+			collector.OnXML(pagination.Next, func(e *colly.XMLElement) {
+				value := e.Attr(pagination.attribute())
+				if value == "" {
+					value = strings.TrimSpace(e.Text)
+				}
+				onNext(e.Request, value)
+			})
+		}
 	} else {
 		collector.OnHTML(entrySelector, func(e *colly.HTMLElement) {
+			current.entries++
 			callback(e)
 		})
+
+		if pagination.Next != "" {
+			collector.OnHTML(pagination.Next, func(e *colly.HTMLElement) {
+				onNext(e.Request, e.Attr(pagination.attribute()))
+			})
+		}
 	}
 
 	var isChallenge bool
@@ -258,33 +340,49 @@ func visit(ctx context.Context, source SourceDefinition, callback func(e Element
 		isChallenge = false
 	})
 
-	urls := source.urls()
-	randGen.Shuffle(len(urls), func(i, j int) { urls[i], urls[j] = urls[j], urls[i] })
-
-	for _, url := range urls {
-		logger.Info().Msgf("Visiting %s", url)
-		for attempt := 0; attempt <= maxRetries; attempt++ {
-			isChallenge = false
-			headers := getBrowserHeaders()
-			err := collector.Request("GET", url, nil, nil, headers)
-			if err != nil {
-				logger.Error().Err(err).Str("url", url).Msg("Fail to visit")
-
-				return fmt.Errorf("error on visit (%s): %w", url, err)
-			}
-			collector.Wait()
-			if !isChallenge {
-				break
+	paths := append([]string{}, source.Paths...)
+	shufflePaths(ctx, paths)
+
+	for _, path := range paths {
+		visited := map[string]bool{}
+		current = page{path: path, number: 1, next: "", entries: 0}
+
+	pages:
+		for url := source.absouteURL(path); url != "" && !satisfied() && !visited[url]; url = source.nextPage(&current) {
+			visited[url] = true
+
+			logger.Info().Msgf("Visiting %s", url)
+			for attempt := 0; attempt <= maxRetries; attempt++ {
+				isChallenge = false
+				current.next = ""
+				current.entries = 0
+				headers := getBrowserHeaders()
+				err := collector.Request("GET", url, nil, nil, headers)
+				if err != nil && current.number > 1 {
+					// a missing next page ends the pagination
+					logger.Warn().Err(err).Str("url", url).Msg("Fail to visit the next page")
+
+					break pages
+				}
+				if err != nil {
+					logger.Error().Err(err).Str("url", url).Msg("Fail to visit")
+
+					return fmt.Errorf("error on visit (%s): %w", url, err)
+				}
+				collector.Wait()
+				if !isChallenge {
+					break
+				}
+				logger.Warn().Msgf("Cloudflare challenge detected, retrying (%d/%d)", attempt+1, maxRetries)
+				sleep(ctx, time.Duration(randGen.Intn(5)+5)*time.Second)
 			}
-			logger.Warn().Msgf("Cloudflare challenge detected, retrying (%d/%d)", attempt+1, maxRetries)
-			time.Sleep(time.Duration(randGen.Intn(5)+5) * time.Second)
-		}
-		if isChallenge {
-			logger.Error().Msgf("Failed to bypass Cloudflare after %d retries", maxRetries)
+			if isChallenge {
+				logger.Error().Msgf("Failed to bypass Cloudflare after %d retries", maxRetries)
 
-			return fmt.Errorf("failed to bypass Cloudflare for %s", url)
+				return fmt.Errorf("%w: %s", ErrCloudflareChallenge, url)
+			}
+			sleep(ctx, time.Duration(randGen.Intn(5))*time.Second) // Atraso após cada requisição
 		}
-		time.Sleep(time.Duration(randGen.Intn(5)) * time.Second) // Atraso após cada requisição
 	}
 
 	collector.Wait()
@@ -309,10 +407,26 @@ func onEntry[T model.IEntry](ctx context.Context, source SourceDefinition, el El
 
 	link := attributes.Link.findAttribute(el)
 	image := attributes.Image.findAttribute(el)
+	if image == "" && isFeedParser(source.Parser) {
+		image = findFeedImage(source.Parser, el)
+	}
+	published := attributes.Published.findDate(el)
+	if published.IsZero() && isFeedParser(source.Parser) {
+		published = findFeedPublished(source.Parser, el)
+	}
 	if len(title) > titleLimit {
 		title = title[:titleLimit]
 	}
-	result = source.buildEntry(title, link, image, categories).(T)
+	result = source.buildEntry(title, link, image, categories, published).(T)
+
+	if !source.Filters.Allow(filters.FromEntry(result)) {
+		zerolog.Ctx(ctx).Debug().
+			Str("title", title).
+			Str("link", result.Link()).
+			Msg(ErrEntryFiltered.Error())
+
+		return result, ErrEntryFiltered
+	}
 
 	return result, nil
 }
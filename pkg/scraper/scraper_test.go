@@ -6,8 +6,10 @@ import (
 	"net/http/httptest"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/vinicius73/gear-feed/pkg/filters"
 	"github.com/vinicius73/gear-feed/pkg/model"
 	"github.com/vinicius73/gear-feed/pkg/scraper"
 	"github.com/vinicius73/gear-feed/pkg/scraper/testdata"
@@ -65,6 +67,48 @@ attributes:
 	}
 }
 
+func (s *FindEntriesTestSuite) TestExample01Published() {
+	source := s.parseSource(`
+name: test_published
+enabled: true
+paths:
+ - /example_01.html
+attributes:
+	entry_selector: "#news > article"
+	link:
+		path: "h2 a"
+		attribute: "href"
+	title:
+		path: "h2 a"
+	published:
+		path_finder:
+			path: "time"
+		format: "02/01/2006 15:04"
+	`)
+
+	entries, err := scraper.FindEntries[model.Entry](context.TODO(), source)
+
+	s.NoError(err)
+
+	s.Len(entries, 3)
+
+	for index, entry := range entries {
+		s.Equal(time.Date(2024, 9, index+1, 10, 0, 0, 0, time.UTC), entry.Published())
+	}
+
+	source.Attributes.Published = scraper.PathFinderDate{
+		PathFinder: scraper.PathFinder{Path: "time", Attribute: "datetime"},
+	}
+
+	entries, err = scraper.FindEntries[model.Entry](context.TODO(), source)
+
+	s.NoError(err)
+
+	for index, entry := range entries {
+		s.Equal(time.Date(2024, 9, index+1, 10, 0, 0, 0, time.UTC), entry.Published().UTC())
+	}
+}
+
 func (s *FindEntriesTestSuite) TestExample02BaseURL() {
 	source := s.parseSource(`
 name: test_01
@@ -285,6 +329,88 @@ attributes:
 	}
 }
 
+func (s *FindEntriesTestSuite) TestExample08RSS() {
+	source := s.parseSource(`
+name: test_rss
+paths:
+  - /example_08.xml
+parser: RSS
+	`)
+
+	entries, err := scraper.FindEntries[model.Entry](context.TODO(), source)
+
+	s.NoError(err)
+
+	s.Len(entries, 4)
+
+	for index, entry := range entries {
+		num := strconv.Itoa(index + 1)
+		s.Equal("RSS in 200"+num, entry.Title)
+		s.Equal("https://rsssite.net/news-"+num+".html", entry.URL)
+		s.Equal("https://rsssite.net/news-"+num+".jpg", entry.Image)
+		s.Equal(time.Date(2024, 9, 2, 9+index+1, 0, 0, 0, time.UTC), entry.Published().UTC())
+	}
+}
+
+func (s *FindEntriesTestSuite) TestExample08RSSOverrides() {
+	source := s.parseSource(`
+name: test_rss_overrides
+paths:
+  - /example_08.xml
+parser: RSS
+attributes:
+	category:
+		allows:
+			- "games"
+	image:
+		path: /media:content
+		attribute: url
+	`)
+
+	entries, err := scraper.FindEntries[model.Entry](context.TODO(), source)
+
+	s.NoError(err)
+
+	s.Len(entries, 3)
+
+	for index, num := range []string{"1", "2", "4"} {
+		entry := entries[index]
+		s.Equal("RSS in 200"+num, entry.Title)
+		s.Equal([]string{"games"}, entry.Categories)
+	}
+
+	s.Equal("https://rsssite.net/news-2.jpg", entries[1].Image)
+}
+
+func (s *FindEntriesTestSuite) TestExample09Atom() {
+	source := s.parseSource(`
+name: test_atom
+paths:
+  - /example_09.xml
+parser: ATOM
+attributes:
+	category:
+		allows:
+			- "games"
+			- "movies"
+	`)
+
+	entries, err := scraper.FindEntries[model.Entry](context.TODO(), source)
+
+	s.NoError(err)
+
+	s.Len(entries, 3)
+
+	for index, entry := range entries {
+		num := strconv.Itoa(index + 1)
+		s.Equal("Atom in 200"+num, entry.Title)
+		s.Equal("https://atomsite.net/news-"+num+".html", entry.URL)
+		s.Equal("https://atomsite.net/news-"+num+".png", entry.Image)
+		s.Len(entry.Categories, 1)
+		s.Equal(time.Date(2024, 9, 2, 9+index+1, 0, 0, 0, time.UTC), entry.Published().UTC())
+	}
+}
+
 func (s *FindEntriesTestSuite) TestExampleJSON() {
 	source := s.parseSource(`
   name: JSONSOURCE
@@ -302,6 +428,9 @@ attributes:
     path: content.lead
   image:
     path: content.thumbnail.filename
+  published:
+    path_finder:
+      path: content.first_published_at
 `)
 
 	entries, err := scraper.FindEntries[model.Entry](context.TODO(), source)
@@ -316,9 +445,73 @@ attributes:
 		s.Equal("A new Entry 00"+num, entry.Title)
 		s.Equal(fmt.Sprintf("%s/latest/2023/9/24/new-entries-00%v", baseURL, num), entry.URL)
 		s.Equal("https://foo.json/image-00"+num+".jpg", entry.Image)
+		s.Equal(time.Date(2023, 9, 20+index+1, 10, 0, 0, 0, time.UTC), entry.Published().UTC())
 	}
 }
 
+func (s *FindEntriesTestSuite) TestExample01Filters() {
+	source := s.parseSource(`
+name: test_filters
+enabled: true
+paths:
+ - /example_01.html
+filters:
+	exclude:
+		- words: ["good news 2"]
+attributes:
+	entry_selector: "#news > article"
+	link:
+		path: "h2 a"
+		attribute: "href"
+	title:
+		path: "h2 a"
+	`)
+
+	entries, err := scraper.FindEntries[model.Entry](scraper.WithoutDelay(context.TODO()), source)
+
+	s.NoError(err)
+	s.Len(entries, 2)
+	s.Equal("Good news 1", entries[0].Title)
+	s.Equal("Good news 3", entries[1].Title)
+
+	source.Filters = filters.Filters{
+		Include: []filters.Rule{{Words: []string{}, Regex: `good-3$`, Fields: []string{filters.FieldURL}}},
+		Exclude: []filters.Rule{},
+	}
+
+	entries, err = scraper.FindEntries[model.Entry](scraper.WithoutDelay(context.TODO()), source)
+
+	s.NoError(err)
+	s.Len(entries, 1)
+	s.Equal("Good news 3", entries[0].Title)
+}
+
+func (s *FindEntriesTestSuite) TestExampleJSONFilters() {
+	source := s.parseSource(`
+name: JSONFILTERS
+paths:
+  - /example.json
+limit: 1
+enabled: true
+parser: JSON
+filters:
+  exclude:
+    - words: ["entry 001"]
+      fields: [title]
+attributes:
+  entry_selector: "stories"
+  link:
+    path: slug
+  title:
+    path: content.lead
+`)
+
+	entries, err := scraper.FindEntries[model.Entry](scraper.WithoutDelay(context.TODO()), source)
+	s.NoError(err)
+	s.Len(entries, 1)
+	s.Equal("A new Entry 002", entries[0].Title)
+}
+
 func TestFindEntriesSuite(t *testing.T) {
 	t.Parallel()
 	suite.Run(t, new(FindEntriesTestSuite))
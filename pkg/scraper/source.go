@@ -3,8 +3,10 @@ package scraper
 import (
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/gocolly/colly"
+	"github.com/vinicius73/gear-feed/pkg/filters"
 	"github.com/vinicius73/gear-feed/pkg/model"
 	"github.com/vinicius73/gear-feed/pkg/support"
 )
@@ -18,20 +20,42 @@ const (
 	ParserStrategyStyle PathParserStrategy = "style"
 )
 
+const (
+	FetcherHTTP   = "http"
+	FetcherChrome = "chrome"
+)
+
 const (
 	XML  = "XML"
 	HTML = "HTML"
+	JSON = "JSON"
+	RSS  = "RSS"
+	ATOM = "ATOM"
 )
 
 type SourceDefinition struct {
-	Name           string           `yaml:"name"`
-	Enabled        bool             `yaml:"enabled"`
-	SupportStories bool             `yaml:"support_stories"`
-	BaseURL        string           `yaml:"base_url"`
-	Paths          []string         `yaml:"paths"`
-	Limit          int              `yaml:"limit"`
-	Parser         string           `yaml:"parser"`
-	Attributes     AttributesFinder `yaml:"attributes"`
+	Name           string   `yaml:"name"`
+	Enabled        bool     `yaml:"enabled"`
+	SupportStories bool     `yaml:"support_stories"`
+	BaseURL        string   `yaml:"base_url"`
+	Paths          []string `yaml:"paths"`
+	Limit          int      `yaml:"limit"`
+	Parser         string   `yaml:"parser"`
+	// Weight of the source in the selection of the entries, 1 by default.
+	Weight int `yaml:"weight,omitempty"`
+	// Priority picks the source first among the sources of the same weight.
+	Priority int `yaml:"priority,omitempty"`
+	// StripParams are removed from the entry links, along with the tracking parameters.
+	StripParams []string `yaml:"strip_params,omitempty"`
+	// Fetcher loads the pages, "chrome" renders them with a headless browser.
+	Fetcher string `yaml:"fetcher,omitempty"`
+	// WaitFor is the selector expected in the rendered page, the entry selector is used when empty.
+	WaitFor    string     `yaml:"wait_for,omitempty"`
+	Pagination Pagination `yaml:"pagination,omitempty"`
+	Enrich     Enrich     `yaml:"enrich,omitempty"`
+	// Filters drops the entries by keywords of the title, link and categories.
+	Filters    filters.Filters  `yaml:"filters,omitempty"`
+	Attributes AttributesFinder `yaml:"attributes"`
 }
 
 type PathFinder struct {
@@ -51,6 +75,7 @@ type AttributesFinder struct {
 	Link          PathFinder         `yaml:"link"`
 	Title         PathFinder         `yaml:"title"`
 	Image         PathFinder         `yaml:"image"`
+	Published     PathFinderDate     `yaml:"published"`
 }
 
 func parseStyle(style string) string {
@@ -72,28 +97,26 @@ type Element interface {
 	ChildText(selector string) string
 }
 
-func (d SourceDefinition) urls() []string {
-	urls := make([]string, len(d.Paths))
-
-	for i, path := range d.Paths {
-		urls[i] = d.absouteURL(path)
-	}
-
-	return urls
-}
-
-func (d SourceDefinition) buildEntry(title, link, image string, categories []string) model.IEntry {
+func (d SourceDefinition) buildEntry(title, link, image string, categories []string, published time.Time) model.IEntry {
 	return model.Entry{
-		SourceName: d.Name,
-		Title:      title,
-		Categories: categories,
-		HaveStory:  false,
-		URL:        d.absouteURL(link),
-		Image:      d.absouteURL(image),
+		SourceName:  d.Name,
+		Title:       title,
+		Categories:  categories,
+		HaveStory:   false,
+		URL:         d.canonicalURL(link),
+		Image:       d.absouteURL(image),
+		PublishedAt: published,
+		Description: "",
+		SiteName:    "",
+		ClusterID:   "",
 	}
 }
 
 func (d SourceDefinition) absouteURL(path string) string {
+	if path == "" {
+		return ""
+	}
+
 	if strings.HasPrefix(path, "http") {
 		return path
 	}
@@ -102,9 +125,17 @@ func (d SourceDefinition) absouteURL(path string) string {
 		return "https:" + path
 	}
 
+	if strings.HasSuffix(d.BaseURL, "/") && strings.HasPrefix(path, "/") {
+		return d.BaseURL + path[1:]
+	}
+
 	return d.BaseURL + path
 }
 
+func (d SourceDefinition) canonicalURL(link string) string {
+	return model.CanonicalURL(d.absouteURL(link), d.StripParams...)
+}
+
 func (option PathFinder) findAttribute(e Element) string {
 	val := option.findAttributeRaw(e)
 
@@ -160,6 +191,10 @@ func (category PathFinderCategory) findCategories(element interface{}) []string
 }
 
 func (category PathFinderCategory) findCategoriesOnXML(element *colly.XMLElement) []string {
+	if len(category.Attribute) > 0 {
+		return element.ChildAttrs(category.Path, category.Attribute)
+	}
+
 	return element.ChildTexts(category.Path)
 }
 
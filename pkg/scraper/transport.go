@@ -0,0 +1,82 @@
+package scraper
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/vinicius73/gear-feed/pkg/scraper/chrome"
+)
+
+type (
+	transportKey struct{}
+	noDelayKey   struct{}
+)
+
+// WithTransport sends the scraper requests through the transport, used to record and replay responses.
+func WithTransport(ctx context.Context, transport http.RoundTripper) context.Context {
+	return context.WithValue(ctx, transportKey{}, transport)
+}
+
+// WithoutDelay disables the pauses between requests, used when the responses are not fetched from the sources.
+func WithoutDelay(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noDelayKey{}, true)
+}
+
+// Transport returns the fetcher of the source, nil means the default http transport.
+// The pages share one browser with the chrome fetcher, the returned func stops it.
+func Transport(source SourceDefinition) (http.RoundTripper, func()) {
+	if !source.usesChrome() {
+		return nil, func() {}
+	}
+
+	waitFor := source.WaitFor
+
+	if waitFor == "" {
+		waitFor = source.Attributes.EntrySelector
+	}
+
+	browser := chrome.FromEnv().Browser()
+
+	return chrome.Transport{Browser: browser, WaitFor: waitFor}, browser.Close
+}
+
+// transportFor gives priority to the context transport, which records or replays the responses.
+func transportFor(ctx context.Context, source SourceDefinition) (http.RoundTripper, func()) {
+	if transport, ok := ctx.Value(transportKey{}).(http.RoundTripper); ok {
+		return transport, func() {}
+	}
+
+	return Transport(source)
+}
+
+// shufflePaths varies the order of the paths, they keep the definition order with a context transport,
+// so a replay requests the same paths as the recording, even when the limit skips some of them.
+func shufflePaths(ctx context.Context, paths []string) {
+	if _, ok := ctx.Value(transportKey{}).(http.RoundTripper); ok {
+		return
+	}
+
+	randGen.Shuffle(len(paths), func(i, j int) { paths[i], paths[j] = paths[j], paths[i] })
+}
+
+func (d SourceDefinition) usesChrome() bool {
+	return strings.EqualFold(d.Fetcher, FetcherChrome)
+}
+
+func requestTimeoutFor(source SourceDefinition) time.Duration {
+	if source.usesChrome() {
+		return chrome.Timeout
+	}
+
+	return requestTimeout
+}
+
+func sleep(ctx context.Context, duration time.Duration) {
+	if noDelay, _ := ctx.Value(noDelayKey{}).(bool); noDelay {
+		return
+	}
+
+	time.Sleep(duration)
+}
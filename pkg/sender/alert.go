@@ -0,0 +1,85 @@
+package sender
+
+import (
+	"html"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const alertErrorSize = 500
+
+// Alert reports a failing task, or its recovery, to the operators.
+type Alert struct {
+	Task      string
+	ErrorCode string
+	Error     string
+	// Failures is the number of consecutive failures.
+	Failures int
+	// Since is when the first failure happened.
+	Since     time.Time
+	Recovered bool
+}
+
+type SendAlertOptions struct {
+	Alert Alert
+	Chats []int64
+}
+
+func (a Alert) HTML() string {
+	var builder strings.Builder
+
+	builder.WriteString(BuildMsgHeader())
+	builder.WriteString("\n\n")
+
+	if a.Recovered {
+		builder.WriteString("✅ <b>Task recovered: </b><code>")
+		builder.WriteString(a.Task)
+		builder.WriteString("</code>\n🔁 <b>Failures: </b><code>")
+		builder.WriteString(strconv.Itoa(a.Failures))
+		builder.WriteString("</code>")
+		builder.WriteString(BuildMsgFooter())
+
+		return builder.String()
+	}
+
+	builder.WriteString("🚨 <b>Task failed: </b><code>")
+	builder.WriteString(a.Task)
+	builder.WriteString("</code>")
+
+	if a.ErrorCode != "" {
+		builder.WriteString("\n🏷 <b>Code: </b><code>")
+		builder.WriteString(html.EscapeString(a.ErrorCode))
+		builder.WriteString("</code>")
+	}
+
+	builder.WriteString("\n🔁 <b>Failures: </b><code>")
+	builder.WriteString(strconv.Itoa(a.Failures))
+	builder.WriteString("</code>")
+
+	if !a.Since.IsZero() {
+		builder.WriteString(" since <code>")
+		builder.WriteString(a.Since.Format(time.RFC3339))
+		builder.WriteString("</code>")
+	}
+
+	if a.Error != "" {
+		builder.WriteString("\n\n<pre>")
+		builder.WriteString(html.EscapeString(truncateRunes(a.Error, alertErrorSize)))
+		builder.WriteString("</pre>")
+	}
+
+	builder.WriteString(BuildMsgFooter())
+
+	return builder.String()
+}
+
+func truncateRunes(text string, size int) string {
+	runes := []rune(text)
+
+	if len(runes) <= size {
+		return text
+	}
+
+	return string(runes[:size]) + "…"
+}
@@ -0,0 +1,122 @@
+package sender
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/vinicius73/gear-feed/pkg/model"
+	"github.com/vinicius73/gear-feed/pkg/support"
+	"github.com/vinicius73/gear-feed/pkg/support/apperrors"
+)
+
+type DestinationType string
+
+const (
+	DestinationTelegram DestinationType = "telegram"
+	DestinationDiscord  DestinationType = "discord"
+	// DestinationMastodon uses the account defined in the mastodon config.
+	DestinationMastodon DestinationType = "mastodon"
+)
+
+const webhookKeySize = 12
+
+var ErrUnknownDestination = apperrors.Business("unknown destination type: %s", "SENDER:UNKNOWN_DESTINATION")
+
+// Destination is a delivery channel of a task.
+type Destination struct {
+	// ID identifies the destination, when empty it is derived from type and targets.
+	ID       string          `fig:"id"       yaml:"id,omitempty"`
+	Type     DestinationType `fig:"type"     yaml:"type"`
+	Chats    []int64         `fig:"chats"    yaml:"chats,omitempty"`
+	Webhooks []string        `fig:"webhooks" yaml:"webhooks,omitempty"`
+}
+
+// Factory builds the sender used to deliver to the destinations.
+type Factory[T model.IEntry] func(destinations []Destination) (Serder[T], error)
+
+// Key names the destination in logs, metrics and results.
+func (d Destination) Key() string {
+	if d.ID != "" {
+		return d.ID
+	}
+
+	switch d.Type {
+	case DestinationTelegram:
+		ids := make([]string, len(d.Chats))
+
+		for index, chat := range d.Chats {
+			ids[index] = strconv.FormatInt(chat, 10)
+		}
+
+		return string(d.Type) + ":" + strings.Join(ids, ",")
+	case DestinationDiscord:
+		// webhook URLs carry a token, only a piece of its hash is exposed
+		hash, _ := support.HashSHA256(strings.Join(d.Webhooks, ","))
+
+		return string(d.Type) + ":" + hash[:webhookKeySize]
+	default:
+		return string(d.Type)
+	}
+}
+
+// Keys returns the keys used to record the deliveries, telegram deliveries are keyed by chat and
+// discord ones by webhook, so they match the keys of the senders, whatever destination sent them.
+func (d Destination) Keys() []string {
+	switch {
+	case d.Type == DestinationTelegram:
+		keys := make([]string, len(d.Chats))
+
+		for index, chat := range d.Chats {
+			keys[index] = telegramKey(strconv.FormatInt(chat, 10))
+		}
+
+		return keys
+	case d.Type == DestinationDiscord && len(d.Webhooks) > 0:
+		keys := make([]string, len(d.Webhooks))
+
+		for index, webhook := range d.Webhooks {
+			keys[index] = webhookKey(webhook)
+		}
+
+		return keys
+	default:
+		return []string{d.Key()}
+	}
+}
+
+// without removes the chats and webhooks of the keys.
+func (d Destination) without(keys []string) Destination {
+	chats := []int64{}
+
+	for _, chat := range d.Chats {
+		if !support.Contains(keys, telegramKey(strconv.FormatInt(chat, 10))) {
+			chats = append(chats, chat)
+		}
+	}
+
+	webhooks := []string{}
+
+	for _, webhook := range d.Webhooks {
+		if !support.Contains(keys, webhookKey(webhook)) {
+			webhooks = append(webhooks, webhook)
+		}
+	}
+
+	d.Chats = chats
+	d.Webhooks = webhooks
+
+	return d
+}
+
+func (d Destination) Validate() error {
+	switch d.Type {
+	case DestinationTelegram, DestinationDiscord, DestinationMastodon:
+		return nil
+	default:
+		return ErrUnknownDestination.Msgf(d.Type)
+	}
+}
+
+func telegramKey(recipient string) string {
+	return string(DestinationTelegram) + ":" + recipient
+}
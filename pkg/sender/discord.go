@@ -0,0 +1,258 @@
+package sender
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/vinicius73/gear-feed/pkg/discord"
+	"github.com/vinicius73/gear-feed/pkg/model"
+	"github.com/vinicius73/gear-feed/pkg/storage"
+	"github.com/vinicius73/gear-feed/pkg/support"
+	"github.com/vinicius73/gear-feed/pkg/support/apperrors"
+)
+
+var _ Serder[model.IEntry] = (*DiscordSerder[model.IEntry])(nil) // Ensure interface implementation
+
+var ErrNoWebhooks = apperrors.Business("no webhooks to send message", "SENDER:NO_WEBHOOKS")
+
+type DiscordSerder[T model.IEntry] struct {
+	webhooks []string
+	storage  storage.Storage[T]
+	client   discord.Client
+}
+
+type DiscordOptions[T model.IEntry] struct {
+	Config  discord.Config
+	Storage storage.Storage[T]
+}
+
+func NewDiscordSerder[T model.IEntry](opts DiscordOptions[T]) DiscordSerder[T] {
+	return DiscordSerder[T]{
+		webhooks: opts.Config.Webhooks,
+		storage:  opts.Storage,
+		client:   discord.NewClient(opts.Config),
+	}
+}
+
+func (s DiscordSerder[T]) Send(ctx context.Context, entry T) error {
+	msg := discord.Message{
+		Embeds: []discord.Embed{BuildEmbed(entry)},
+	}
+
+	sent, err := s.execute(ctx, msg)
+	if len(sent) == 0 {
+		return err
+	}
+
+	zerolog.Ctx(ctx).Info().
+		Strs("tags", entry.Tags()).
+		Int("webhooks", len(sent)).
+		Msgf("Discord message sent %s", entry.Link())
+
+	if err != nil {
+		// the webhooks that succeeded are recorded, so a retry does not post to them again
+		return partialSent(s.storage, entry, s.deliveries(storage.DeliveryMessage, sent), err)
+	}
+
+	return storeSent(s.storage, entry, s.deliveries(storage.DeliveryMessage, sent))
+}
+
+func (s DiscordSerder[T]) SendCollection(ctx context.Context, entries []T) error {
+	logger := zerolog.Ctx(ctx)
+
+	if len(entries) == 0 {
+		logger.Warn().Msg("No entries to send")
+
+		return nil
+	}
+
+	sendInterval := CalculeSendInterval(len(entries))
+
+	for _, item := range entries {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(sendInterval):
+			if err := s.Send(ctx, item); err != nil {
+				logger.Error().Err(err).Msg("Error sending message")
+
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s DiscordSerder[T]) SendStory(ctx context.Context, story Story[T]) error {
+	msg := discord.Message{
+		Embeds: []discord.Embed{BuildEmbed(story.Entry)},
+	}
+
+	// the video replaces the embed image
+	msg.Embeds[0].Image = nil
+
+	sent, err := s.execute(ctx, msg, story.Story.Video)
+	if len(sent) == 0 {
+		return err
+	}
+
+	zerolog.Ctx(ctx).Info().
+		Strs("tags", story.Entry.Tags()).
+		Int("webhooks", len(sent)).
+		Msgf("Discord story sent %s", story.Entry.Link())
+
+	entry := story.Entry.SetHasStory(true).(T)
+
+	if err != nil {
+		return partialSent(s.storage, entry, s.deliveries(storage.DeliveryStory, sent), err)
+	}
+
+	return updateSent(s.storage, entry, s.deliveries(storage.DeliveryStory, sent))
+}
+
+func (s DiscordSerder[T]) SendResume(ctx context.Context, opt SendResumeOptions) error {
+	_, err := s.execute(ctx, discord.Message{
+		Content: discord.Truncate(discord.FromHTML(opt.Resume.HTML()), discord.ContentLimit),
+	})
+
+	return err
+}
+
+// SendAlert is delivered to the webhooks, chats are ignored.
+func (s DiscordSerder[T]) SendAlert(ctx context.Context, opt SendAlertOptions) error {
+	_, err := s.execute(ctx, discord.Message{
+		Content: discord.Truncate(discord.FromHTML(opt.Alert.HTML()), discord.ContentLimit),
+	})
+
+	return err
+}
+
+func (s DiscordSerder[T]) SendCleanupNotify(ctx context.Context, opt SendCleanupNotifyOptions) error {
+	_, err := s.execute(ctx, discord.Message{
+		Content: discord.FromHTML(BuildCleanupMessage(opt.Count)),
+	})
+
+	return err
+}
+
+func (s DiscordSerder[T]) SendFile(ctx context.Context, opt SendFileOptions) error {
+	_, err := s.execute(ctx, discord.Message{
+		Content: discord.Truncate(discord.FromHTML(opt.Caption), discord.ContentLimit),
+	}, opt.FilePath)
+
+	return err
+}
+
+// WithChats is a no-op, discord messages are delivered to the configured webhooks.
+func (s DiscordSerder[T]) WithChats(_ []int64) Serder[T] {
+	return s
+}
+
+func (s DiscordSerder[T]) WithoutDestinations(keys []string) Serder[T] {
+	webhooks := []string{}
+
+	for _, webhook := range s.webhooks {
+		if !support.Contains(keys, webhookKey(webhook)) {
+			webhooks = append(webhooks, webhook)
+		}
+	}
+
+	s.webhooks = webhooks
+
+	return s
+}
+
+// Destinations returns a key for each webhook.
+func (s DiscordSerder[T]) Destinations() []string {
+	keys := make([]string, len(s.webhooks))
+
+	for index, webhook := range s.webhooks {
+		keys[index] = webhookKey(webhook)
+	}
+
+	return keys
+}
+
+type webhookMessage struct {
+	webhook string
+	id      string
+}
+
+// execute posts the message to all webhooks, returning the messages created
+// and the errors of the webhooks that failed.
+func (s DiscordSerder[T]) execute(ctx context.Context, msg discord.Message, files ...string) ([]webhookMessage, error) {
+	if len(s.webhooks) == 0 {
+		return nil, ErrNoWebhooks
+	}
+
+	sent := []webhookMessage{}
+	errs := []error{}
+
+	for _, webhook := range s.webhooks {
+		id, err := s.client.Execute(ctx, webhook, msg, files...)
+		if err != nil {
+			zerolog.Ctx(ctx).Error().Err(err).Str("webhook", webhookKey(webhook)).Msg("Fail to execute discord webhook")
+
+			errs = append(errs, err)
+
+			continue
+		}
+
+		sent = append(sent, webhookMessage{webhook: webhook, id: id})
+	}
+
+	if len(errs) > 0 {
+		return sent, ErrFailToSend.Wrap(errors.Join(errs...))
+	}
+
+	return sent, nil
+}
+
+func (s DiscordSerder[T]) deliveries(kind storage.DeliveryKind, sent []webhookMessage) []storage.Delivery {
+	deliveries := make([]storage.Delivery, len(sent))
+
+	for index, message := range sent {
+		deliveries[index] = storage.Delivery{
+			Destination: webhookKey(message.webhook),
+			Kind:        kind,
+			MessageID:   message.id,
+			SentAt:      time.Now(),
+		}
+	}
+
+	return deliveries
+}
+
+func webhookKey(webhook string) string {
+	return Destination{Type: DestinationDiscord, Webhooks: []string{webhook}}.Key()
+}
+
+// BuildEmbed renders the entry as a discord rich embed.
+func BuildEmbed(entry model.IEntry) discord.Embed {
+	tags := make([]string, len(entry.Tags()))
+
+	for index, tag := range entry.Tags() {
+		tags[index] = "#" + tag
+	}
+
+	embed := discord.Embed{
+		Title:       discord.Truncate(entry.Text(), discord.EmbedTitleLimit),
+		URL:         entry.Link(),
+		Description: support.Truncate(strings.TrimSpace(entry.Summary()), summaryLimit),
+		Footer:      &discord.EmbedFooter{Text: strings.Join(tags, " ")},
+	}
+
+	if image := entry.ImageURL(); image != "" {
+		embed.Image = &discord.EmbedImage{URL: image}
+	}
+
+	if published := entry.Published(); !published.IsZero() {
+		embed.Timestamp = published.UTC().Format(time.RFC3339)
+	}
+
+	return embed
+}
@@ -0,0 +1,128 @@
+package sender_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vinicius73/gear-feed/pkg/discord"
+	"github.com/vinicius73/gear-feed/pkg/model"
+	"github.com/vinicius73/gear-feed/pkg/sender"
+	"github.com/vinicius73/gear-feed/pkg/storage"
+)
+
+func TestBuildEmbed(t *testing.T) {
+	t.Parallel()
+
+	embed := sender.BuildEmbed(model.Entry{
+		Title:       "Entry 1",
+		URL:         "https://foo.bar/1",
+		Image:       "https://foo.bar/1.jpg",
+		SourceName:  "FOO",
+		PublishedAt: time.Date(2024, 9, 2, 10, 0, 0, 0, time.UTC),
+	})
+
+	assert.Equal(t, "Entry 1", embed.Title)
+	assert.Equal(t, "https://foo.bar/1", embed.URL)
+	assert.Equal(t, "https://foo.bar/1.jpg", embed.Image.URL)
+	assert.Equal(t, "#FOO", embed.Footer.Text)
+	assert.Equal(t, "2024-09-02T10:00:00Z", embed.Timestamp)
+
+	embed = sender.BuildEmbed(model.Entry{Title: "No image", SourceName: "BAR"})
+
+	assert.Nil(t, embed.Image)
+	assert.Empty(t, embed.Timestamp)
+}
+
+func TestDiscordSerderSendPartialFailure(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("POST /webhooks/fail", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	mux.HandleFunc("POST /webhooks/ok", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"id": "200"}`))
+	})
+
+	server := httptest.NewServer(mux)
+
+	defer server.Close()
+
+	store := &memoryStorage{}
+	ok := server.URL + "/webhooks/ok"
+
+	serder := sender.NewDiscordSerder(sender.DiscordOptions[model.Entry]{
+		Config:  discord.Config{Webhooks: []string{server.URL + "/webhooks/fail", ok}},
+		Storage: store,
+	})
+
+	entry := model.Entry{Title: "Entry 1", URL: "https://foo.bar/1", SourceName: "FOO"}
+
+	err := serder.Send(context.TODO(), entry)
+
+	require.ErrorContains(t, err, sender.ErrFailToSend.ErrorCode)
+	assert.Equal(t, []model.Entry{entry}, store.stored)
+	require.Len(t, store.deliveries, 1)
+	assert.Equal(t, sender.Destination{Type: sender.DestinationDiscord, Webhooks: []string{ok}}.Key(), store.deliveries[0].Destination)
+	assert.Equal(t, "200", store.deliveries[0].MessageID)
+	assert.Equal(t, storage.DeliveryMessage, store.deliveries[0].Kind)
+}
+
+func TestMultiSerderDiscordPartialFailure(t *testing.T) {
+	t.Parallel()
+
+	var okCalls, failCalls atomic.Int32
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("POST /webhooks/fail", func(w http.ResponseWriter, _ *http.Request) {
+		failCalls.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	mux.HandleFunc("POST /webhooks/ok", func(w http.ResponseWriter, _ *http.Request) {
+		okCalls.Add(1)
+		_, _ = w.Write([]byte(`{"id": "200"}`))
+	})
+
+	server := httptest.NewServer(mux)
+
+	defer server.Close()
+
+	store := &memoryStorage{}
+	webhooks := []string{server.URL + "/webhooks/fail", server.URL + "/webhooks/ok"}
+	destination := sender.Destination{ID: "news", Type: sender.DestinationDiscord, Webhooks: webhooks}
+
+	serder := sender.NewMultiSerder(sender.MultiOptions[model.Entry]{
+		Storage: store,
+		Targets: []sender.Target[model.Entry]{{
+			Destination: destination,
+			Serder: sender.NewDiscordSerder(sender.DiscordOptions[model.Entry]{
+				Config:  discord.Config{Webhooks: webhooks},
+				Storage: nil,
+			}),
+		}},
+	})
+
+	entry := model.Entry{Title: "Entry 1", URL: "https://foo.bar/1", SourceName: "FOO"}
+	okKey := sender.Destination{Type: sender.DestinationDiscord, Webhooks: webhooks[1:]}.Key()
+
+	require.ErrorContains(t, serder.Send(context.TODO(), entry), sender.ErrFailToSend.ErrorCode)
+	require.Len(t, store.deliveries, 1)
+	assert.Equal(t, okKey, store.deliveries[0].Destination)
+	assert.Equal(t, "200", store.deliveries[0].MessageID)
+
+	// the retry only posts to the webhook that failed
+	require.ErrorContains(t, serder.Send(context.TODO(), entry), sender.ErrFailToSend.ErrorCode)
+	assert.Equal(t, int32(1), okCalls.Load())
+	assert.Equal(t, int32(2), failCalls.Load())
+	assert.Len(t, store.deliveries, 1)
+}
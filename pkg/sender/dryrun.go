@@ -0,0 +1,288 @@
+package sender
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/vinicius73/gear-feed/pkg/model"
+	"github.com/vinicius73/gear-feed/pkg/storage"
+	"github.com/vinicius73/gear-feed/pkg/support"
+)
+
+var _ Serder[model.IEntry] = (*DryRunSerder[model.IEntry])(nil) // Ensure interface implementation
+
+// DryRunSerder renders every message instead of posting it.
+// Messages are written to Output, or to files inside Dir when it is defined.
+type DryRunSerder[T model.IEntry] struct {
+	chats       []int64
+	destination string
+	out         io.Writer
+	dir         string
+	store       bool
+	storage     storage.Storage[T]
+	seq         *atomic.Int64
+}
+
+type DryRunOptions[T model.IEntry] struct {
+	Chats  []int64
+	Output io.Writer
+	Dir    string
+	// Destination labels the recipients, chats are not required when it is defined.
+	Destination string
+	// Store marks entries as sent, like a real sender does.
+	Store   bool
+	Storage storage.Storage[T]
+}
+
+func NewDryRunSerder[T model.IEntry](opts DryRunOptions[T]) (DryRunSerder[T], error) {
+	out := opts.Output
+
+	if out == nil {
+		out = os.Stdout
+	}
+
+	if opts.Dir != "" {
+		if err := support.DirMustExist(opts.Dir); err != nil {
+			return DryRunSerder[T]{}, err
+		}
+	}
+
+	return DryRunSerder[T]{
+		chats:       opts.Chats,
+		destination: opts.Destination,
+		out:         out,
+		dir:         opts.Dir,
+		store:       opts.Store,
+		storage:     opts.Storage,
+		seq:         &atomic.Int64{},
+	}, nil
+}
+
+func (s DryRunSerder[T]) Send(ctx context.Context, entry T) error {
+	if s.noRecipients(s.chats) {
+		return ErrNoChats
+	}
+
+	if err := s.write(ctx, "message", s.chats, BuildMessage(entry), nil); err != nil {
+		return err
+	}
+
+	if !s.store {
+		return nil
+	}
+
+	return storeSent(s.storage, entry, s.deliveries(storage.DeliveryMessage))
+}
+
+func (s DryRunSerder[T]) SendCollection(ctx context.Context, entries []T) error {
+	for _, entry := range entries {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := s.Send(ctx, entry); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s DryRunSerder[T]) SendStory(ctx context.Context, story Story[T]) error {
+	if s.noRecipients(s.chats) {
+		return ErrNoChats
+	}
+
+	media := []string{story.Story.Video, story.Story.Stage.Full}
+
+	if err := s.write(ctx, "story", s.chats, BuildMessage(story.Entry), media); err != nil {
+		return err
+	}
+
+	if !s.store {
+		return nil
+	}
+
+	return updateSent(s.storage, story.Entry.SetHasStory(true).(T), s.deliveries(storage.DeliveryStory))
+}
+
+func (s DryRunSerder[T]) SendResume(ctx context.Context, opt SendResumeOptions) error {
+	chats := s.chats
+
+	if len(opt.Chats) > 0 {
+		chats = opt.Chats
+	}
+
+	if s.noRecipients(chats) {
+		return ErrNoChats
+	}
+
+	return s.write(ctx, "resume", chats, opt.Resume.HTML(), nil)
+}
+
+func (s DryRunSerder[T]) SendAlert(ctx context.Context, opt SendAlertOptions) error {
+	chats := s.chats
+
+	if len(opt.Chats) > 0 {
+		chats = opt.Chats
+	}
+
+	if s.noRecipients(chats) {
+		return ErrNoChats
+	}
+
+	return s.write(ctx, "alert", chats, opt.Alert.HTML(), nil)
+}
+
+func (s DryRunSerder[T]) SendCleanupNotify(ctx context.Context, opt SendCleanupNotifyOptions) error {
+	if s.noRecipients(s.chats) {
+		return ErrNoChats
+	}
+
+	return s.write(ctx, "cleanup", s.chats, BuildCleanupMessage(opt.Count), nil)
+}
+
+func (s DryRunSerder[T]) SendFile(ctx context.Context, opt SendFileOptions) error {
+	return s.write(ctx, "file", s.chats, opt.Caption, []string{opt.FilePath})
+}
+
+// WithChats add chats to send messages.
+func (s DryRunSerder[T]) WithChats(ids []int64) Serder[T] {
+	chats := make([]int64, 0, len(s.chats)+len(ids))
+	chats = append(chats, s.chats...)
+	s.chats = append(chats, ids...)
+
+	return s
+}
+
+// WithoutDestinations removes the chats of the telegram keys.
+func (s DryRunSerder[T]) WithoutDestinations(keys []string) Serder[T] {
+	chats := []int64{}
+
+	for _, chat := range s.chats {
+		if !support.Contains(keys, telegramKey(strconv.FormatInt(chat, 10))) {
+			chats = append(chats, chat)
+		}
+	}
+
+	s.chats = chats
+
+	return s
+}
+
+// Destinations mimics the telegram keys, or uses the destination when defined.
+func (s DryRunSerder[T]) Destinations() []string {
+	if s.destination != "" {
+		return []string{s.destination}
+	}
+
+	keys := make([]string, len(s.chats))
+
+	for index, chat := range s.chats {
+		keys[index] = telegramKey(strconv.FormatInt(chat, 10))
+	}
+
+	return keys
+}
+
+func (s DryRunSerder[T]) deliveries(kind storage.DeliveryKind) []storage.Delivery {
+	keys := s.Destinations()
+	deliveries := make([]storage.Delivery, len(keys))
+
+	for index, key := range keys {
+		deliveries[index] = storage.Delivery{
+			Destination: key,
+			Kind:        kind,
+			SentAt:      time.Now(),
+		}
+	}
+
+	return deliveries
+}
+
+func (s DryRunSerder[T]) write(ctx context.Context, kind string, chats []int64, text string, media []string) error {
+	logger := zerolog.Ctx(ctx).With().Str("kind", kind).Logger()
+	recipients := formatChats(chats)
+
+	if s.destination != "" {
+		recipients = s.destination + " " + recipients
+	}
+
+	if s.dir == "" {
+		_, err := fmt.Fprintf(s.out, "--- %s -> %s ---\n%s\n", kind, recipients, text)
+		if err != nil {
+			return err
+		}
+
+		for _, file := range media {
+			if _, err = fmt.Fprintf(s.out, "[media] %s\n", file); err != nil {
+				return err
+			}
+		}
+
+		logger.Info().Str("recipients", recipients).Msg("Dry run: message rendered")
+
+		return nil
+	}
+
+	prefix := filepath.Join(s.dir, fmt.Sprintf("%s-%03d-%s", time.Now().Format("20060102150405"), s.seq.Add(1), kind))
+
+	content := "chats: " + recipients + "\n\n" + text + "\n"
+
+	//nolint:gosec,gomnd
+	if err := os.WriteFile(prefix+".txt", []byte(content), 0o644); err != nil {
+		return ErrFailToSend.Wrap(err)
+	}
+
+	for _, file := range media {
+		if err := copyFile(file, prefix+"--"+filepath.Base(file)); err != nil {
+			return ErrFailToSend.Wrap(err)
+		}
+	}
+
+	logger.Info().Str("recipients", recipients).Str("file", prefix+".txt").Msg("Dry run: message stored")
+
+	return nil
+}
+
+func (s DryRunSerder[T]) noRecipients(chats []int64) bool {
+	return len(chats) == 0 && s.destination == ""
+}
+
+func formatChats(chats []int64) string {
+	ids := make([]string, len(chats))
+
+	for index, chat := range chats {
+		ids[index] = strconv.FormatInt(chat, 10)
+	}
+
+	return "[" + strings.Join(ids, ", ") + "]"
+}
+
+func copyFile(source, target string) error {
+	in, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+
+	defer in.Close()
+
+	out, err := os.Create(target)
+	if err != nil {
+		return err
+	}
+
+	defer out.Close()
+
+	_, err = io.Copy(out, in)
+
+	return err
+}
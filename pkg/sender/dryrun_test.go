@@ -0,0 +1,110 @@
+package sender_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vinicius73/gear-feed/pkg/model"
+	"github.com/vinicius73/gear-feed/pkg/sender"
+)
+
+func TestDryRunSerderOutput(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+
+	serder, err := sender.NewDryRunSerder(sender.DryRunOptions[model.Entry]{
+		Chats:  []int64{10},
+		Output: &out,
+	})
+
+	assert.NoError(t, err)
+
+	err = serder.WithChats([]int64{20}).SendCollection(context.TODO(), []model.Entry{
+		{Title: "Entry 1", URL: "https://foo.bar/1", SourceName: "FOO"},
+		{Title: "Entry 2", URL: "https://foo.bar/2", SourceName: "BAR"},
+	})
+
+	assert.NoError(t, err)
+
+	assert.Equal(t, "--- message -> [10, 20] ---\nEntry 1\nhttps://foo.bar/1\n#FOO\n"+
+		"--- message -> [10, 20] ---\nEntry 2\nhttps://foo.bar/2\n#BAR\n", out.String())
+}
+
+func TestDryRunSerderNoChats(t *testing.T) {
+	t.Parallel()
+
+	serder, err := sender.NewDryRunSerder(sender.DryRunOptions[model.Entry]{
+		Output: &bytes.Buffer{},
+	})
+
+	assert.NoError(t, err)
+	assert.ErrorIs(t, serder.Send(context.TODO(), model.Entry{}), sender.ErrNoChats)
+}
+
+func TestDryRunSerderDir(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	backup := filepath.Join(t.TempDir(), "backup.tar")
+
+	assert.NoError(t, os.WriteFile(backup, []byte("data"), 0o600))
+
+	serder, err := sender.NewDryRunSerder(sender.DryRunOptions[model.Entry]{
+		Chats: []int64{10},
+		Dir:   filepath.Join(dir, "outputs"),
+	})
+
+	assert.NoError(t, err)
+
+	err = serder.SendFile(context.TODO(), sender.SendFileOptions{
+		FilePath: backup,
+		Caption:  "<b>backup</b>",
+	})
+
+	assert.NoError(t, err)
+
+	captions, _ := filepath.Glob(filepath.Join(dir, "outputs", "*-001-file.txt"))
+	files, _ := filepath.Glob(filepath.Join(dir, "outputs", "*-001-file--backup.tar"))
+
+	assert.Len(t, captions, 1)
+	assert.Len(t, files, 1)
+
+	content, err := os.ReadFile(captions[0])
+
+	assert.NoError(t, err)
+	assert.Equal(t, "chats: [10]\n\n<b>backup</b>\n", string(content))
+}
+
+func TestDryRunSerderAlert(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+
+	serder, err := sender.NewDryRunSerder(sender.DryRunOptions[model.Entry]{
+		Chats:  []int64{10},
+		Output: &out,
+	})
+
+	assert.NoError(t, err)
+
+	err = serder.SendAlert(context.TODO(), sender.SendAlertOptions{
+		Chats: []int64{99},
+		Alert: sender.Alert{
+			Task:      "send_last_entries",
+			ErrorCode: "SENDER:FAIL_TO_SEND",
+			Error:     "SENDER:FAIL_TO_SEND: fail to send message (<nil>)",
+			Failures:  3,
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.Contains(t, out.String(), "--- alert -> [99] ---")
+	assert.Contains(t, out.String(), "🚨 <b>Task failed: </b><code>send_last_entries</code>")
+	assert.Contains(t, out.String(), "🔁 <b>Failures: </b><code>3</code>")
+	assert.Contains(t, out.String(), "<pre>SENDER:FAIL_TO_SEND: fail to send message (&lt;nil&gt;)</pre>")
+}
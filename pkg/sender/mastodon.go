@@ -0,0 +1,231 @@
+package sender
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/vinicius73/gear-feed/pkg/mastodon"
+	"github.com/vinicius73/gear-feed/pkg/model"
+	"github.com/vinicius73/gear-feed/pkg/storage"
+	"github.com/vinicius73/gear-feed/pkg/support/apperrors"
+)
+
+var _ Serder[model.IEntry] = (*MastodonSerder[model.IEntry])(nil) // Ensure interface implementation
+
+var ErrFileNotSupported = apperrors.Business("mastodon does not support files", "SENDER:FILE_NOT_SUPPORTED")
+
+const (
+	// linkSize is the length counted by mastodon for any link.
+	linkSize = 23
+	// statusTitleSize is kept for the title, the hashtags that do not fit are dropped.
+	statusTitleSize = 100
+)
+
+type MastodonSerder[T model.IEntry] struct {
+	client  mastodon.Client
+	storage storage.Storage[T]
+}
+
+type MastodonOptions[T model.IEntry] struct {
+	Config  mastodon.Config
+	Storage storage.Storage[T]
+}
+
+func NewMastodonSerder[T model.IEntry](opts MastodonOptions[T]) (MastodonSerder[T], error) {
+	if !opts.Config.Enabled() {
+		return MastodonSerder[T]{}, mastodon.ErrNotConfigured
+	}
+
+	return MastodonSerder[T]{
+		client:  mastodon.NewClient(opts.Config),
+		storage: opts.Storage,
+	}, nil
+}
+
+func (s MastodonSerder[T]) Send(ctx context.Context, entry T) error {
+	logger := zerolog.Ctx(ctx)
+
+	status := mastodon.Status{
+		Status:         BuildStatus(entry),
+		IdempotencyKey: idempotencyKey(entry, storage.DeliveryMessage),
+	}
+
+	// the status is posted without image when the upload fails
+	if image := entry.ImageURL(); image != "" {
+		media, err := s.client.UploadURL(ctx, image, entry.Text())
+		if err != nil {
+			logger.Warn().Err(err).Str("image", image).Msg("Fail to upload image")
+		} else {
+			status.MediaIDs = []string{media.ID}
+		}
+	}
+
+	posted, err := s.client.PostStatus(ctx, status)
+	if err != nil {
+		return ErrFailToSend.Wrap(err)
+	}
+
+	logger.Info().
+		Str("status", posted.URL).
+		Strs("tags", entry.Tags()).
+		Msgf("Mastodon status posted %s", entry.Link())
+
+	return storeSent(s.storage, entry, s.deliveries(storage.DeliveryMessage, posted))
+}
+
+func (s MastodonSerder[T]) SendCollection(ctx context.Context, entries []T) error {
+	logger := zerolog.Ctx(ctx)
+
+	if len(entries) == 0 {
+		logger.Warn().Msg("No entries to send")
+
+		return nil
+	}
+
+	sendInterval := CalculeSendInterval(len(entries))
+
+	for _, item := range entries {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(sendInterval):
+			if err := s.Send(ctx, item); err != nil {
+				logger.Error().Err(err).Msg("Error sending message")
+
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s MastodonSerder[T]) SendStory(ctx context.Context, story Story[T]) error {
+	media, err := s.client.UploadFile(ctx, story.Story.Video, story.Entry.Text())
+	if err != nil {
+		return ErrFailToSend.Wrap(err)
+	}
+
+	posted, err := s.client.PostStatus(ctx, mastodon.Status{
+		Status:         BuildStatus(story.Entry),
+		MediaIDs:       []string{media.ID},
+		IdempotencyKey: idempotencyKey(story.Entry, storage.DeliveryStory),
+	})
+	if err != nil {
+		return ErrFailToSend.Wrap(err)
+	}
+
+	zerolog.Ctx(ctx).Info().
+		Str("status", posted.URL).
+		Strs("tags", story.Entry.Tags()).
+		Msgf("Mastodon story posted %s", story.Entry.Link())
+
+	entry := story.Entry.SetHasStory(true).(T)
+
+	return updateSent(s.storage, entry, s.deliveries(storage.DeliveryStory, posted))
+}
+
+// SendResume is posted as a direct status, only visible by the account.
+func (s MastodonSerder[T]) SendResume(ctx context.Context, opt SendResumeOptions) error {
+	return s.direct(ctx, opt.Resume.HTML())
+}
+
+// SendAlert is posted as a direct status, only visible by the account.
+func (s MastodonSerder[T]) SendAlert(ctx context.Context, opt SendAlertOptions) error {
+	return s.direct(ctx, opt.Alert.HTML())
+}
+
+// SendCleanupNotify is posted as a direct status, only visible by the account.
+func (s MastodonSerder[T]) SendCleanupNotify(ctx context.Context, opt SendCleanupNotifyOptions) error {
+	return s.direct(ctx, BuildCleanupMessage(opt.Count))
+}
+
+// SendFile is not supported, mastodon only accepts media attachments.
+func (s MastodonSerder[T]) SendFile(_ context.Context, _ SendFileOptions) error {
+	return ErrFileNotSupported
+}
+
+// WithChats is a no-op, statuses are posted by the configured account.
+func (s MastodonSerder[T]) WithChats(_ []int64) Serder[T] {
+	return s
+}
+
+// WithoutDestinations is a no-op, the account is the only recipient.
+func (s MastodonSerder[T]) WithoutDestinations(_ []string) Serder[T] {
+	return s
+}
+
+func (s MastodonSerder[T]) Destinations() []string {
+	return []string{Destination{Type: DestinationMastodon}.Key()}
+}
+
+func (s MastodonSerder[T]) direct(ctx context.Context, html string) error {
+	_, err := s.client.PostStatus(ctx, mastodon.Status{
+		Status:     mastodon.Truncate(mastodon.FromHTML(html), mastodon.StatusLimit),
+		Visibility: mastodon.VisibilityDirect,
+	})
+	if err != nil {
+		return ErrFailToSend.Wrap(err)
+	}
+
+	return nil
+}
+
+func (s MastodonSerder[T]) deliveries(kind storage.DeliveryKind, posted mastodon.Posted) []storage.Delivery {
+	return []storage.Delivery{{
+		Destination: Destination{Type: DestinationMastodon}.Key(),
+		Kind:        kind,
+		MessageID:   posted.ID,
+		SentAt:      time.Now(),
+	}}
+}
+
+// BuildStatus renders the entry as a status, the title is truncated to fit the limit.
+func BuildStatus(entry model.IEntry) string {
+	// line breaks between title, link and hashtags
+	available := mastodon.StatusLimit - linkSize - 4
+	tags := make([]string, 0, len(entry.Tags()))
+	size := 0
+
+	for _, tag := range entry.Tags() {
+		if tag = mastodon.Hashtag(tag); tag == "" {
+			continue
+		}
+
+		// the hashtag with its # and the space before it
+		tagSize := len([]rune(tag)) + 2
+		if available-size-tagSize < statusTitleSize {
+			break
+		}
+
+		tags = append(tags, "#"+tag)
+		size += tagSize
+	}
+
+	hashtags := strings.Join(tags, " ")
+	available -= len([]rune(hashtags))
+
+	var builder strings.Builder
+
+	builder.WriteString(mastodon.Truncate(entry.Text(), available))
+	builder.WriteString("\n\n")
+	builder.WriteString(entry.Link())
+
+	if hashtags != "" {
+		builder.WriteString("\n\n")
+		builder.WriteString(hashtags)
+	}
+
+	return builder.String()
+}
+
+func idempotencyKey(entry model.IEntry, kind storage.DeliveryKind) string {
+	hash, err := entry.Hash()
+	if err != nil {
+		return ""
+	}
+
+	return string(kind) + ":" + hash
+}
@@ -0,0 +1,134 @@
+package sender_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vinicius73/gear-feed/pkg/mastodon"
+	"github.com/vinicius73/gear-feed/pkg/model"
+	"github.com/vinicius73/gear-feed/pkg/sender"
+	"github.com/vinicius73/gear-feed/pkg/storage"
+)
+
+func TestMastodonSerderSend(t *testing.T) {
+	t.Parallel()
+
+	var status map[string]any
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /images/1.jpg", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("image-data"))
+	})
+
+	mux.HandleFunc("POST /api/v2/media", func(w http.ResponseWriter, r *http.Request) {
+		_, header, err := r.FormFile("file")
+		assert.NoError(t, err)
+		assert.Equal(t, "1.jpg", header.Filename)
+
+		_, _ = w.Write([]byte(`{"id": "5", "type": "image", "url": "https://social.test/5.jpg"}`))
+	})
+
+	mux.HandleFunc("POST /api/v1/statuses", func(w http.ResponseWriter, r *http.Request) {
+		assert.NotEmpty(t, r.Header.Get("Idempotency-Key"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&status))
+
+		_, _ = w.Write([]byte(`{"id": "100", "url": "https://social.test/@gfeed/100"}`))
+	})
+
+	server := httptest.NewServer(mux)
+
+	defer server.Close()
+
+	store := &memoryStorage{}
+
+	serder, err := sender.NewMastodonSerder(sender.MastodonOptions[model.Entry]{
+		Config:  mastodon.Config{Server: server.URL, Token: "secret", Visibility: mastodon.VisibilityPublic, Language: "pt"},
+		Storage: store,
+	})
+	require.NoError(t, err)
+
+	entry := model.Entry{
+		Title:      "Entry 1",
+		URL:        "https://foo.bar/1",
+		Image:      server.URL + "/images/1.jpg",
+		SourceName: "GAME-VICIO",
+	}
+
+	require.NoError(t, serder.Send(context.TODO(), entry))
+
+	assert.Equal(t, map[string]any{
+		"status":     "Entry 1\n\nhttps://foo.bar/1\n\n#GAMEVICIO",
+		"media_ids":  []any{"5"},
+		"visibility": "public",
+		"language":   "pt",
+	}, status)
+
+	assert.Equal(t, []model.Entry{entry}, store.stored)
+	require.Len(t, store.deliveries, 1)
+	assert.Equal(t, "mastodon", store.deliveries[0].Destination)
+	assert.Equal(t, "100", store.deliveries[0].MessageID)
+	assert.Equal(t, storage.DeliveryMessage, store.deliveries[0].Kind)
+}
+
+func TestMastodonSerderNotConfigured(t *testing.T) {
+	t.Parallel()
+
+	_, err := sender.NewMastodonSerder(sender.MastodonOptions[model.Entry]{})
+
+	assert.ErrorIs(t, err, mastodon.ErrNotConfigured)
+}
+
+func TestBuildStatusLimit(t *testing.T) {
+	t.Parallel()
+
+	status := sender.BuildStatus(model.Entry{
+		Title:      strings.Repeat("a", 600),
+		URL:        "https://foo.bar/1",
+		SourceName: "FOO",
+	})
+
+	assert.True(t, strings.HasSuffix(status, "…\n\nhttps://foo.bar/1\n\n#FOO"))
+	assert.LessOrEqual(t, len([]rune(status))-len("https://foo.bar/1")+23, mastodon.StatusLimit)
+}
+
+type taggedEntry struct {
+	model.Entry
+	tags []string
+}
+
+func (e taggedEntry) Tags() []string {
+	return e.tags
+}
+
+func TestBuildStatusManyTags(t *testing.T) {
+	t.Parallel()
+
+	tags := make([]string, 200)
+
+	for index := range tags {
+		tags[index] = fmt.Sprintf("category%d", index)
+	}
+
+	status := sender.BuildStatus(taggedEntry{
+		Entry: model.Entry{Title: strings.Repeat("a", 600), URL: "https://foo.bar/1", SourceName: "FOO"},
+		tags:  tags,
+	})
+
+	parts := strings.Split(status, "\n\n")
+	require.Len(t, parts, 3)
+
+	// the hashtags that do not fit are dropped, keeping part of the title
+	assert.GreaterOrEqual(t, len([]rune(parts[0])), 100)
+	assert.True(t, strings.HasSuffix(parts[0], "a…"))
+	assert.True(t, strings.HasPrefix(parts[2], "#category0 #category1 "))
+	assert.NotContains(t, parts[2], "#category199")
+	assert.LessOrEqual(t, len([]rune(status))-len("https://foo.bar/1")+23, mastodon.StatusLimit)
+}
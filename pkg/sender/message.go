@@ -7,13 +7,27 @@ import (
 
 	"github.com/vinicius73/gear-feed/pkg"
 	"github.com/vinicius73/gear-feed/pkg/model"
+	"github.com/vinicius73/gear-feed/pkg/support"
 )
 
+// summaryLimit keeps the message short, it is also the caption of the stories.
+const summaryLimit = 280
+
 func BuildMessage(entry model.IEntry) string {
 	var builder strings.Builder
 
 	builder.WriteString(entry.Text())
 	builder.WriteString("\n")
+
+	if summary := strings.TrimSpace(entry.Summary()); summary != "" {
+		builder.WriteString(support.Truncate(summary, summaryLimit))
+		builder.WriteString("\n")
+	}
+
+	if site := entry.Site(); site != "" {
+		builder.WriteString("via " + site + "\n")
+	}
+
 	builder.WriteString(entry.Link())
 	builder.WriteString("\n")
 	for index, tag := range entry.Tags() {
@@ -0,0 +1,23 @@
+package sender_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vinicius73/gear-feed/pkg/model"
+	"github.com/vinicius73/gear-feed/pkg/sender"
+)
+
+func TestBuildMessage(t *testing.T) {
+	t.Parallel()
+
+	entry := model.Entry{Title: "Entry 1", URL: "https://foo.bar/1", SourceName: "FOO"}
+
+	assert.Equal(t, "Entry 1\nhttps://foo.bar/1\n#FOO", sender.BuildMessage(entry))
+
+	entry.Description = strings.Repeat("a", 300)
+	entry.SiteName = "Foo News"
+
+	assert.Equal(t, "Entry 1\n"+strings.Repeat("a", 279)+"…\nvia Foo News\nhttps://foo.bar/1\n#FOO", sender.BuildMessage(entry))
+}
@@ -0,0 +1,401 @@
+package sender
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/vinicius73/gear-feed/pkg/model"
+	"github.com/vinicius73/gear-feed/pkg/storage"
+)
+
+var _ Serder[model.IEntry] = (*MultiSerder[model.IEntry])(nil) // Ensure interface implementation
+
+// Target is a sender bound to a destination.
+// Target senders should not have storage, entries are stored by MultiSerder.
+type Target[T model.IEntry] struct {
+	Destination Destination
+	Serder      Serder[T]
+}
+
+type DeliveryResult struct {
+	Destination string
+	Sent        int
+	Failed      int
+	LastError   error
+}
+
+// MultiSerder delivers to every target, an entry is marked as sent
+// when at least one destination receives it.
+// Destinations that already received the entry are skipped.
+type MultiSerder[T model.IEntry] struct {
+	targets []Target[T]
+	storage storage.Storage[T]
+	results *deliveryResults
+}
+
+type MultiOptions[T model.IEntry] struct {
+	Targets []Target[T]
+	// Storage is optional, entries are not stored when nil.
+	Storage storage.Storage[T]
+}
+
+type deliveryResults struct {
+	mu    sync.Mutex
+	items map[string]*DeliveryResult
+}
+
+func NewMultiSerder[T model.IEntry](opts MultiOptions[T]) MultiSerder[T] {
+	return MultiSerder[T]{
+		targets: opts.Targets,
+		storage: opts.Storage,
+		results: &deliveryResults{items: map[string]*DeliveryResult{}},
+	}
+}
+
+func (s MultiSerder[T]) Send(ctx context.Context, entry T) error {
+	targets, err := s.pending(ctx, entry, storage.DeliveryMessage)
+	if err != nil || len(targets) == 0 {
+		return err
+	}
+
+	list := []storage.Delivery{}
+
+	_, err = s.each(ctx, targets, func(target Target[T]) error {
+		err := target.Serder.Send(ctx, entry)
+		list = append(list, targetDeliveries(target, storage.DeliveryMessage, err)...)
+
+		return err
+	})
+	if len(list) == 0 {
+		return err
+	}
+
+	if storeErr := storeSent(s.storage, entry, list); storeErr != nil {
+		return storeErr
+	}
+
+	return err
+}
+
+func (s MultiSerder[T]) SendCollection(ctx context.Context, entries []T) error {
+	logger := zerolog.Ctx(ctx)
+
+	if len(entries) == 0 {
+		logger.Warn().Msg("No entries to send")
+
+		return nil
+	}
+
+	sendInterval := CalculeSendInterval(len(entries))
+
+	for _, item := range entries {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(sendInterval):
+			if err := s.Send(ctx, item); err != nil {
+				logger.Error().Err(err).Msg("Error sending message")
+
+				return err
+			}
+		}
+	}
+
+	for _, result := range s.Results() {
+		logger.Info().
+			Str("destination", result.Destination).
+			Int("sent", result.Sent).
+			Int("failed", result.Failed).
+			Msg("Delivery summary")
+	}
+
+	return nil
+}
+
+func (s MultiSerder[T]) SendStory(ctx context.Context, story Story[T]) error {
+	targets, err := s.pending(ctx, story.Entry, storage.DeliveryStory)
+	if err != nil || len(targets) == 0 {
+		return err
+	}
+
+	list := []storage.Delivery{}
+
+	_, err = s.each(ctx, targets, func(target Target[T]) error {
+		err := target.Serder.SendStory(ctx, story)
+		list = append(list, targetDeliveries(target, storage.DeliveryStory, err)...)
+
+		return err
+	})
+	if len(list) == 0 {
+		return err
+	}
+
+	entry := story.Entry.SetHasStory(true).(T)
+
+	if storeErr := updateSent(s.storage, entry, list); storeErr != nil {
+		return storeErr
+	}
+
+	return err
+}
+
+// SendResume with chats is delivered once, by the first telegram destination.
+func (s MultiSerder[T]) SendResume(ctx context.Context, opt SendResumeOptions) error {
+	_, err := s.each(ctx, s.forChats(opt.Chats), func(target Target[T]) error {
+		return target.Serder.SendResume(ctx, opt)
+	})
+
+	return err
+}
+
+// SendAlert with chats is delivered once, by the first telegram destination.
+func (s MultiSerder[T]) SendAlert(ctx context.Context, opt SendAlertOptions) error {
+	_, err := s.each(ctx, s.forChats(opt.Chats), func(target Target[T]) error {
+		return target.Serder.SendAlert(ctx, opt)
+	})
+
+	return err
+}
+
+func (s MultiSerder[T]) SendCleanupNotify(ctx context.Context, opt SendCleanupNotifyOptions) error {
+	_, err := s.each(ctx, s.targets, func(target Target[T]) error {
+		return target.Serder.SendCleanupNotify(ctx, opt)
+	})
+
+	return err
+}
+
+func (s MultiSerder[T]) SendFile(ctx context.Context, opt SendFileOptions) error {
+	_, err := s.each(ctx, s.targets, func(target Target[T]) error {
+		return target.Serder.SendFile(ctx, opt)
+	})
+
+	return err
+}
+
+// WithChats add chats to the telegram destinations.
+func (s MultiSerder[T]) WithChats(ids []int64) Serder[T] {
+	targets := make([]Target[T], len(s.targets))
+
+	for index, target := range s.targets {
+		if target.Destination.Type == DestinationTelegram {
+			target.Serder = target.Serder.WithChats(ids)
+			// the chats are part of the delivery keys
+			target.Destination.Chats = append(append([]int64{}, target.Destination.Chats...), ids...)
+		}
+
+		targets[index] = target
+	}
+
+	s.targets = targets
+
+	return s
+}
+
+func (s MultiSerder[T]) WithoutDestinations(keys []string) Serder[T] {
+	targets := make([]Target[T], len(s.targets))
+
+	for index, target := range s.targets {
+		targets[index] = target.without(keys)
+	}
+
+	s.targets = targets
+
+	return s
+}
+
+// Destinations returns the delivery keys of all targets.
+func (s MultiSerder[T]) Destinations() []string {
+	keys := []string{}
+
+	for _, target := range s.targets {
+		keys = append(keys, target.Destination.Keys()...)
+	}
+
+	return keys
+}
+
+// Results returns the deliveries made by each destination.
+func (s MultiSerder[T]) Results() []DeliveryResult {
+	s.results.mu.Lock()
+	defer s.results.mu.Unlock()
+
+	list := make([]DeliveryResult, 0, len(s.targets))
+
+	for _, target := range s.targets {
+		if result, ok := s.results.items[target.Destination.Key()]; ok {
+			list = append(list, *result)
+		}
+	}
+
+	return list
+}
+
+// forChats returns the first telegram target when chats are defined, otherwise all targets.
+func (s MultiSerder[T]) forChats(chats []int64) []Target[T] {
+	if len(chats) == 0 {
+		return s.targets
+	}
+
+	for _, target := range s.targets {
+		if target.Destination.Type == DestinationTelegram {
+			return []Target[T]{target}
+		}
+	}
+
+	return s.targets
+}
+
+// pending returns the targets that did not receive the entry yet,
+// the recipients that already received it are removed from them.
+func (s MultiSerder[T]) pending(ctx context.Context, entry T, kind storage.DeliveryKind) ([]Target[T], error) {
+	if len(s.targets) == 0 {
+		return nil, ErrNoDestinations
+	}
+
+	if s.storage == nil {
+		return s.targets, nil
+	}
+
+	hash, err := entry.Hash()
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := s.storage.Deliveries(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	sent := map[string]bool{}
+
+	for _, record := range records {
+		if record.Kind == kind {
+			sent[record.Destination] = true
+		}
+	}
+
+	targets := []Target[T]{}
+
+	for _, target := range s.targets {
+		keys := target.Destination.Keys()
+		delivered := []string{}
+
+		for _, key := range keys {
+			if sent[key] {
+				delivered = append(delivered, key)
+			}
+		}
+
+		switch len(delivered) {
+		case 0:
+			targets = append(targets, target)
+		case len(keys):
+			zerolog.Ctx(ctx).Debug().
+				Str("destination", target.Destination.Key()).
+				Msgf("Already delivered %s", entry.Link())
+		default:
+			targets = append(targets, target.without(delivered))
+		}
+	}
+
+	return targets, nil
+}
+
+// each runs fn for every target, returning the ones that succeeded.
+// The error is only returned when all targets fail.
+func (s MultiSerder[T]) each(ctx context.Context, targets []Target[T], fn func(target Target[T]) error) ([]Target[T], error) {
+	if len(targets) == 0 {
+		return nil, ErrNoDestinations
+	}
+
+	var (
+		delivered []Target[T]
+		errs      []error
+	)
+
+	for _, target := range targets {
+		if err := ctx.Err(); err != nil {
+			return delivered, err
+		}
+
+		key := target.Destination.Key()
+		err := fn(target)
+
+		s.results.add(key, err)
+
+		if err != nil {
+			zerolog.Ctx(ctx).Error().Err(err).Str("destination", key).Msg("Fail to deliver")
+
+			errs = append(errs, err)
+
+			continue
+		}
+
+		delivered = append(delivered, target)
+	}
+
+	if len(delivered) == 0 {
+		return nil, ErrFailToSend.Wrap(errors.Join(errs...))
+	}
+
+	return delivered, nil
+}
+
+// targetDeliveries returns a delivery for each key of the target, when it fails
+// only the deliveries reported by its DeliveryError are returned.
+func targetDeliveries[T model.IEntry](target Target[T], kind storage.DeliveryKind, err error) []storage.Delivery {
+	if err != nil {
+		var partial DeliveryError
+
+		if !errors.As(err, &partial) {
+			return nil
+		}
+
+		return partial.Deliveries
+	}
+
+	keys := target.Destination.Keys()
+	list := make([]storage.Delivery, len(keys))
+
+	for index, key := range keys {
+		list[index] = storage.Delivery{
+			Destination: key,
+			Kind:        kind,
+			SentAt:      time.Now(),
+		}
+	}
+
+	return list
+}
+
+// without skips the recipients of the keys, in the sender and in the destination.
+func (t Target[T]) without(keys []string) Target[T] {
+	return Target[T]{
+		Destination: t.Destination.without(keys),
+		Serder:      t.Serder.WithoutDestinations(keys),
+	}
+}
+
+func (r *deliveryResults) add(key string, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result, ok := r.items[key]
+	if !ok {
+		result = &DeliveryResult{Destination: key}
+		r.items[key] = result
+	}
+
+	if err != nil {
+		result.Failed++
+		result.LastError = err
+
+		return
+	}
+
+	result.Sent++
+}
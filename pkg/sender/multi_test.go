@@ -0,0 +1,252 @@
+package sender_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vinicius73/gear-feed/pkg/metrics"
+	"github.com/vinicius73/gear-feed/pkg/model"
+	"github.com/vinicius73/gear-feed/pkg/sender"
+	"github.com/vinicius73/gear-feed/pkg/storage"
+)
+
+var errOffline = errors.New("offline")
+
+type failSerder struct {
+	sender.DryRunSerder[model.Entry]
+}
+
+func (failSerder) Send(_ context.Context, _ model.Entry) error {
+	return errOffline
+}
+
+type memoryStorage struct {
+	storage.Storage[model.Entry]
+	stored     []model.Entry
+	deliveries []storage.Delivery
+}
+
+func (m *memoryStorage) Store(entry storage.Entry[model.Entry]) error {
+	m.stored = append(m.stored, entry.Data)
+
+	return nil
+}
+
+func (m *memoryStorage) StoreDelivery(delivery storage.Delivery) error {
+	m.deliveries = append(m.deliveries, delivery)
+
+	return nil
+}
+
+func (m *memoryStorage) Deliveries(hash string) ([]storage.Delivery, error) {
+	list := []storage.Delivery{}
+
+	for _, delivery := range m.deliveries {
+		if delivery.Hash == hash {
+			list = append(list, delivery)
+		}
+	}
+
+	return list, nil
+}
+
+func destinations(deliveries []storage.Delivery) []string {
+	list := make([]string, len(deliveries))
+
+	for index, delivery := range deliveries {
+		list[index] = delivery.Destination
+	}
+
+	return list
+}
+
+func newTarget(t *testing.T, destination sender.Destination, out *bytes.Buffer) sender.Target[model.Entry] {
+	t.Helper()
+
+	serder, err := sender.NewDryRunSerder(sender.DryRunOptions[model.Entry]{
+		Chats:       destination.Chats,
+		Destination: destination.Key(),
+		Output:      out,
+	})
+
+	assert.NoError(t, err)
+
+	return sender.Target[model.Entry]{Destination: destination, Serder: serder}
+}
+
+func TestMultiSerderSend(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+
+	store := &memoryStorage{}
+	entry := model.Entry{Title: "Entry 1", URL: "https://foo.bar/1", SourceName: "FOO"}
+
+	offline := sender.Destination{ID: "offline", Type: sender.DestinationDiscord}
+
+	serder := sender.NewMultiSerder(sender.MultiOptions[model.Entry]{
+		Storage: store,
+		Targets: []sender.Target[model.Entry]{
+			newTarget(t, sender.Destination{Type: sender.DestinationTelegram, Chats: []int64{10}}, &out),
+			{Destination: offline, Serder: failSerder{}},
+			newTarget(t, sender.Destination{ID: "discord", Type: sender.DestinationDiscord}, &out),
+		},
+	})
+
+	assert.NoError(t, serder.Send(context.TODO(), entry))
+
+	assert.Equal(t, "--- message -> telegram:10 [10] ---\nEntry 1\nhttps://foo.bar/1\n#FOO\n"+
+		"--- message -> discord [] ---\nEntry 1\nhttps://foo.bar/1\n#FOO\n", out.String())
+
+	assert.Equal(t, []model.Entry{entry}, store.stored)
+	assert.Equal(t, []string{"telegram:10", "discord"}, destinations(store.deliveries))
+	assert.Equal(t, []string{"telegram:10", "offline", "discord"}, serder.Destinations())
+
+	assert.Equal(t, []sender.DeliveryResult{
+		{Destination: "telegram:10", Sent: 1},
+		{Destination: "offline", Failed: 1, LastError: errOffline},
+		{Destination: "discord", Sent: 1},
+	}, serder.Results())
+}
+
+func TestMultiSerderSkipDelivered(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+
+	entry := model.Entry{Title: "Entry 1", URL: "https://foo.bar/1", SourceName: "FOO"}
+
+	hash, err := entry.Hash()
+	assert.NoError(t, err)
+
+	store := &memoryStorage{
+		deliveries: []storage.Delivery{{Hash: hash, Destination: "telegram:10", Kind: storage.DeliveryMessage}},
+	}
+
+	serder := sender.NewMultiSerder(sender.MultiOptions[model.Entry]{
+		Storage: store,
+		Targets: []sender.Target[model.Entry]{
+			newTarget(t, sender.Destination{Type: sender.DestinationTelegram, Chats: []int64{10}}, &out),
+			newTarget(t, sender.Destination{Type: sender.DestinationTelegram, Chats: []int64{20}}, &out),
+		},
+	})
+
+	assert.NoError(t, serder.Send(context.TODO(), entry))
+	assert.Equal(t, "--- message -> telegram:20 [20] ---\nEntry 1\nhttps://foo.bar/1\n#FOO\n", out.String())
+	assert.Equal(t, []string{"telegram:10", "telegram:20"}, destinations(store.deliveries))
+}
+
+func TestMultiSerderWithChats(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+
+	entry := model.Entry{Title: "Entry 1", URL: "https://foo.bar/1", SourceName: "FOO"}
+
+	hash, err := entry.Hash()
+	assert.NoError(t, err)
+
+	store := &memoryStorage{
+		deliveries: []storage.Delivery{{Hash: hash, Destination: "telegram:10", Kind: storage.DeliveryMessage}},
+	}
+
+	serder := sender.NewMultiSerder(sender.MultiOptions[model.Entry]{
+		Storage: store,
+		Targets: []sender.Target[model.Entry]{
+			newTarget(t, sender.Destination{ID: "main", Type: sender.DestinationTelegram, Chats: []int64{10}}, &out),
+		},
+	}).WithChats([]int64{20})
+
+	assert.Equal(t, []string{"telegram:10", "telegram:20"}, serder.Destinations())
+
+	// only the new chat receives the entry
+	assert.NoError(t, serder.Send(context.TODO(), entry))
+	assert.Equal(t, "--- message -> main [20] ---\nEntry 1\nhttps://foo.bar/1\n#FOO\n", out.String())
+	assert.Equal(t, []string{"telegram:10", "telegram:20"}, destinations(store.deliveries))
+
+	out.Reset()
+
+	assert.NoError(t, serder.Send(context.TODO(), entry))
+	assert.Empty(t, out.String())
+}
+
+func TestMultiSerderAllFailed(t *testing.T) {
+	t.Parallel()
+
+	store := &memoryStorage{}
+
+	serder := sender.NewMultiSerder(sender.MultiOptions[model.Entry]{
+		Storage: store,
+		Targets: []sender.Target[model.Entry]{
+			{Destination: sender.Destination{ID: "a", Type: sender.DestinationTelegram}, Serder: failSerder{}},
+			{Destination: sender.Destination{ID: "b", Type: sender.DestinationDiscord}, Serder: failSerder{}},
+		},
+	})
+
+	err := serder.Send(context.TODO(), model.Entry{Title: "Entry 1"})
+
+	assert.ErrorContains(t, err, sender.ErrFailToSend.ErrorCode)
+	assert.ErrorContains(t, err, errOffline.Error())
+	assert.Empty(t, store.stored)
+}
+
+func TestMultiSerderNoDestinations(t *testing.T) {
+	t.Parallel()
+
+	serder := sender.NewMultiSerder(sender.MultiOptions[model.Entry]{})
+
+	assert.ErrorIs(t, serder.Send(context.TODO(), model.Entry{}), sender.ErrNoDestinations)
+}
+
+func TestDestinationKey(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "main", sender.Destination{ID: "main", Type: sender.DestinationTelegram}.Key())
+	assert.Equal(t, "telegram:10,20", sender.Destination{Type: sender.DestinationTelegram, Chats: []int64{10, 20}}.Key())
+	assert.Equal(t, []string{"telegram:10", "telegram:20"}, sender.Destination{
+		ID:    "main",
+		Type:  sender.DestinationTelegram,
+		Chats: []int64{10, 20},
+	}.Keys())
+	assert.Equal(t, []string{"discord"}, sender.Destination{ID: "discord", Type: sender.DestinationDiscord}.Keys())
+	assert.Equal(t, []string{
+		sender.Destination{Type: sender.DestinationDiscord, Webhooks: []string{"https://discord.com/api/webhooks/1/a"}}.Key(),
+		sender.Destination{Type: sender.DestinationDiscord, Webhooks: []string{"https://discord.com/api/webhooks/2/b"}}.Key(),
+	}, sender.Destination{
+		ID:       "discord",
+		Type:     sender.DestinationDiscord,
+		Webhooks: []string{"https://discord.com/api/webhooks/1/a", "https://discord.com/api/webhooks/2/b"},
+	}.Keys())
+	assert.Regexp(t, `^discord:[0-9a-f]{12}$`, sender.Destination{
+		Type:     sender.DestinationDiscord,
+		Webhooks: []string{"https://discord.com/api/webhooks/1/token"},
+	}.Key())
+	assert.ErrorIs(t, sender.Destination{Type: "slack"}.Validate(), sender.ErrUnknownDestination.Msgf("slack"))
+}
+
+func TestInstrumentedSerder(t *testing.T) {
+	t.Parallel()
+
+	entry := model.Entry{Title: "Instrumented", URL: "https://example.com/instrumented"}
+
+	var out bytes.Buffer
+
+	target := newTarget(t, sender.Destination{Type: sender.DestinationTelegram, Chats: []int64{10}}, &out)
+
+	ok := sender.Instrument(target.Serder, "test:ok")
+	fail := sender.Instrument[model.Entry](failSerder{}, "test:fail")
+
+	assert.NoError(t, ok.Send(context.TODO(), entry))
+	assert.ErrorIs(t, fail.Send(context.TODO(), entry), errOffline)
+
+	var buf bytes.Buffer
+
+	_, err := metrics.Default.WriteTo(&buf)
+
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), `gfeed_send_total{destination="test:ok",kind="message",result="success"} 1`)
+	assert.Contains(t, buf.String(), `gfeed_send_total{destination="test:fail",kind="message",result="failure"} 1`)
+}
@@ -0,0 +1,116 @@
+package sender
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/vinicius73/gear-feed/pkg/metrics"
+	"github.com/vinicius73/gear-feed/pkg/model"
+)
+
+var _ Serder[model.IEntry] = (*ObservedSerder[model.IEntry])(nil) // Ensure interface implementation
+
+type Kind string
+
+const (
+	KindMessage Kind = "message"
+	KindStory   Kind = "story"
+	KindResume  Kind = "resume"
+	KindCleanup Kind = "cleanup"
+	KindAlert   Kind = "alert"
+	KindFile    Kind = "file"
+)
+
+// Observer is notified about each delivery, err is nil on success.
+type Observer func(kind Kind, err error)
+
+// ObservedSerder notifies the observer about the deliveries of the wrapped sender.
+type ObservedSerder[T model.IEntry] struct {
+	serder   Serder[T]
+	observer Observer
+}
+
+func Observe[T model.IEntry](serder Serder[T], observer Observer) ObservedSerder[T] {
+	return ObservedSerder[T]{
+		serder:   serder,
+		observer: observer,
+	}
+}
+
+// Instrument counts the successes and failures of the sender, destination is used as metric label.
+func Instrument[T model.IEntry](serder Serder[T], destination string) ObservedSerder[T] {
+	return Observe(serder, func(kind Kind, err error) {
+		metrics.SendTotal.Inc(destination, string(kind), metrics.Result(err))
+	})
+}
+
+func (s ObservedSerder[T]) Send(ctx context.Context, entry T) error {
+	return s.observe(KindMessage, s.serder.Send(ctx, entry))
+}
+
+// SendCollection sends each entry through Send, so every delivery is observed.
+func (s ObservedSerder[T]) SendCollection(ctx context.Context, entries []T) error {
+	logger := zerolog.Ctx(ctx)
+
+	if len(entries) == 0 {
+		logger.Warn().Msg("No entries to send")
+
+		return nil
+	}
+
+	sendInterval := CalculeSendInterval(len(entries))
+
+	for _, item := range entries {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(sendInterval):
+			if err := s.Send(ctx, item); err != nil {
+				logger.Error().Err(err).Msg("Error sending message")
+
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s ObservedSerder[T]) SendResume(ctx context.Context, opt SendResumeOptions) error {
+	return s.observe(KindResume, s.serder.SendResume(ctx, opt))
+}
+
+func (s ObservedSerder[T]) SendCleanupNotify(ctx context.Context, opt SendCleanupNotifyOptions) error {
+	return s.observe(KindCleanup, s.serder.SendCleanupNotify(ctx, opt))
+}
+
+func (s ObservedSerder[T]) SendAlert(ctx context.Context, opt SendAlertOptions) error {
+	return s.observe(KindAlert, s.serder.SendAlert(ctx, opt))
+}
+
+func (s ObservedSerder[T]) SendFile(ctx context.Context, opt SendFileOptions) error {
+	return s.observe(KindFile, s.serder.SendFile(ctx, opt))
+}
+
+func (s ObservedSerder[T]) SendStory(ctx context.Context, story Story[T]) error {
+	return s.observe(KindStory, s.serder.SendStory(ctx, story))
+}
+
+func (s ObservedSerder[T]) WithChats(ids []int64) Serder[T] {
+	return Observe(s.serder.WithChats(ids), s.observer)
+}
+
+func (s ObservedSerder[T]) WithoutDestinations(keys []string) Serder[T] {
+	return Observe(s.serder.WithoutDestinations(keys), s.observer)
+}
+
+func (s ObservedSerder[T]) Destinations() []string {
+	return s.serder.Destinations()
+}
+
+func (s ObservedSerder[T]) observe(kind Kind, err error) error {
+	s.observer(kind, err)
+
+	return err
+}
@@ -1,14 +1,25 @@
 package sender
 
 import (
+	"html"
 	"strconv"
 	"strings"
+	"time"
 )
 
 type Resume struct {
-	Loaded   int
-	Filtered int
-	Sources  []ResumeSource
+	Loaded      int
+	Filtered    int
+	Duplicates  int
+	Sources     []ResumeSource
+	Failed      []string
+	Quarantined []ResumeQuarantine
+}
+
+type ResumeQuarantine struct {
+	Source   string
+	Failures int
+	Until    time.Time
 }
 
 type ResumeSource struct {
@@ -36,6 +47,32 @@ func (r Resume) HTML() string {
 		builder.WriteString(source.HTML())
 	}
 
+	if r.Duplicates > 0 {
+		builder.WriteString("\n\n♻️ <b>Duplicates:</b> <code>")
+		builder.WriteString(strconv.Itoa(r.Duplicates))
+		builder.WriteString("</code>")
+	}
+
+	if len(r.Failed) > 0 {
+		builder.WriteString("\n\n⚠️ <b>Failed:</b> ")
+		failed := make([]string, len(r.Failed))
+
+		for index, name := range r.Failed {
+			failed[index] = html.EscapeString(name)
+		}
+
+		builder.WriteString(strings.Join(failed, ", "))
+	}
+
+	if len(r.Quarantined) > 0 {
+		builder.WriteString("\n\n🚧 <b>Quarantined:</b>")
+
+		for _, source := range r.Quarantined {
+			builder.WriteRune('\n')
+			builder.WriteString(source.HTML())
+		}
+	}
+
 	builder.WriteString(BuildMsgFooter())
 
 	return builder.String()
@@ -54,3 +91,17 @@ func (r ResumeSource) HTML() string {
 
 	return builder.String()
 }
+
+func (r ResumeQuarantine) HTML() string {
+	var builder strings.Builder
+
+	builder.WriteString("- <b>")
+	builder.WriteString(html.EscapeString(r.Source))
+	builder.WriteString("</b>: <code>")
+	builder.WriteString(strconv.Itoa(r.Failures))
+	builder.WriteString(" failures, until ")
+	builder.WriteString(r.Until.Format(time.DateTime))
+	builder.WriteString("</code>")
+
+	return builder.String()
+}
@@ -0,0 +1,23 @@
+package sender_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vinicius73/gear-feed/pkg/sender"
+)
+
+func TestResumeHTMLEscapesSources(t *testing.T) {
+	t.Parallel()
+
+	text := sender.Resume{
+		Failed: []string{"A&B", "<C>"},
+		Quarantined: []sender.ResumeQuarantine{
+			{Source: "D<E>", Failures: 3, Until: time.Date(2024, 9, 2, 10, 0, 0, 0, time.UTC)},
+		},
+	}.HTML()
+
+	assert.Contains(t, text, "<b>Failed:</b> A&amp;B, &lt;C&gt;")
+	assert.Contains(t, text, "- <b>D&lt;E&gt;</b>: <code>3 failures, until 2024-09-02 10:00:00</code>")
+}
@@ -3,11 +3,13 @@ package sender
 import (
 	"context"
 	"path/filepath"
+	"strconv"
 	"time"
 
 	"github.com/rs/zerolog"
 	"github.com/vinicius73/gear-feed/pkg/model"
 	"github.com/vinicius73/gear-feed/pkg/storage"
+	"github.com/vinicius73/gear-feed/pkg/support"
 	"github.com/vinicius73/gear-feed/pkg/support/apperrors"
 	"gopkg.in/telebot.v3"
 )
@@ -15,8 +17,9 @@ import (
 var _ Serder[model.IEntry] = (*TelegramSerder[model.IEntry])(nil) // Ensure interface implementation
 
 var (
-	ErrNoChats    = apperrors.Business("no chats to send message", "SENDER:NO_CHATS")
-	ErrFailToSend = apperrors.System(nil, "fail to send message", "SENDER:FAIL_TO_SEND")
+	ErrNoChats        = apperrors.Business("no chats to send message", "SENDER:NO_CHATS")
+	ErrNoDestinations = apperrors.Business("no destinations to send message", "SENDER:NO_DESTINATIONS")
+	ErrFailToSend     = apperrors.System(nil, "fail to send message", "SENDER:FAIL_TO_SEND")
 )
 
 type SendFileOptions struct {
@@ -38,9 +41,29 @@ type Serder[T model.IEntry] interface {
 	SendCollection(ctx context.Context, entry []T) error
 	SendResume(ctx context.Context, opt SendResumeOptions) error
 	SendCleanupNotify(ctx context.Context, opt SendCleanupNotifyOptions) error
+	SendAlert(ctx context.Context, opt SendAlertOptions) error
 	SendFile(ctx context.Context, opt SendFileOptions) error
 	SendStory(ctx context.Context, story Story[T]) error
 	WithChats(ids []int64) Serder[T]
+	// WithoutDestinations skips the recipients of the delivery keys.
+	WithoutDestinations(keys []string) Serder[T]
+	// Destinations returns the keys used to record the deliveries.
+	Destinations() []string
+}
+
+// DeliveryError is returned when only some recipients received the entry,
+// Deliveries are the ones that succeeded.
+type DeliveryError struct {
+	Deliveries []storage.Delivery
+	Err        error
+}
+
+func (e DeliveryError) Error() string {
+	return e.Err.Error()
+}
+
+func (e DeliveryError) Unwrap() error {
+	return e.Err
 }
 
 type TelegramSerder[T model.IEntry] struct {
@@ -99,28 +122,23 @@ func (s TelegramSerder[T]) Send(ctx context.Context, entry T) error {
 	}
 
 	msg := BuildMessage(entry)
+	deliveries := make([]storage.Delivery, len(s.chats))
 
-	for _, chat := range s.chats {
-		_, err := s.bot.Send(chat, msg)
+	for index, chat := range s.chats {
+		sent, err := s.bot.Send(chat, msg)
 		if err != nil {
-			return ErrFailToSend.Wrap(err)
+			return partialSent(s.storage, entry, deliveries[:index], ErrFailToSend.Wrap(err))
 		}
 
 		logger.Info().
 			Str("recipient", chat.Recipient()).
 			Strs("tags", entry.Tags()).
 			Msgf("Message sent %s", entry.Link())
-	}
 
-	err := s.storage.Store(storage.Entry[T]{
-		Data:   entry,
-		Status: storage.StatusSent,
-	})
-	if err != nil {
-		return err
+		deliveries[index] = telegramDelivery(chat, storage.DeliveryMessage, sent)
 	}
 
-	return nil
+	return storeSent(s.storage, entry, deliveries)
 }
 
 func (s TelegramSerder[T]) SendStory(ctx context.Context, story Story[T]) error {
@@ -139,23 +157,23 @@ func (s TelegramSerder[T]) SendStory(ctx context.Context, story Story[T]) error
 		},
 	}
 
-	for _, chat := range s.chats {
-		if _, err := s.bot.Send(chat, video); err != nil {
-			return ErrFailToSend.Wrap(err)
+	deliveries := make([]storage.Delivery, len(s.chats))
+
+	for index, chat := range s.chats {
+		sent, err := s.bot.Send(chat, video)
+		if err != nil {
+			return partialSent(s.storage, story.Entry.SetHasStory(true).(T), deliveries[:index], ErrFailToSend.Wrap(err))
 		}
 
 		logger.Info().
 			Str("recipient", chat.Recipient()).
 			Strs("tags", story.Entry.Tags()).
 			Msgf("Story sent %s", story.Entry.Link())
-	}
 
-	entry := story.Entry.SetHasStory(true).(T)
+		deliveries[index] = telegramDelivery(chat, storage.DeliveryStory, sent)
+	}
 
-	return s.storage.Update(storage.Entry[T]{
-		Data:   entry,
-		Status: storage.StatusSent,
-	})
+	return updateSent(s.storage, story.Entry.SetHasStory(true).(T), deliveries)
 }
 
 func (s TelegramSerder[T]) SendCollection(ctx context.Context, entries []T) error {
@@ -230,6 +248,39 @@ func (s TelegramSerder[T]) SendResume(ctx context.Context, opt SendResumeOptions
 	return nil
 }
 
+func (s TelegramSerder[T]) SendAlert(ctx context.Context, opt SendAlertOptions) error {
+	logger := zerolog.Ctx(ctx)
+
+	chats := s.chats
+
+	if len(opt.Chats) > 0 {
+		chats = make([]telebot.Recipient, len(opt.Chats))
+
+		for index, chat := range opt.Chats {
+			chats[index] = telebot.ChatID(chat)
+		}
+	}
+
+	if len(chats) == 0 {
+		return ErrNoChats
+	}
+
+	msg := opt.Alert.HTML()
+
+	for _, chat := range chats {
+		_, err := s.bot.Send(chat, msg, telebot.ModeHTML)
+		if err != nil {
+			return ErrFailToSend.Wrap(err)
+		}
+
+		logger.Info().
+			Str("recipient", chat.Recipient()).
+			Msgf("Alert sent")
+	}
+
+	return nil
+}
+
 func (s TelegramSerder[T]) SendCleanupNotify(ctx context.Context, opt SendCleanupNotifyOptions) error {
 	logger := zerolog.Ctx(ctx)
 
@@ -265,3 +316,105 @@ func (s TelegramSerder[T]) WithChats(ids []int64) Serder[T] {
 
 	return s
 }
+
+func (s TelegramSerder[T]) WithoutDestinations(keys []string) Serder[T] {
+	chats := []telebot.Recipient{}
+
+	for _, chat := range s.chats {
+		if !support.Contains(keys, telegramKey(chat.Recipient())) {
+			chats = append(chats, chat)
+		}
+	}
+
+	s.chats = chats
+
+	return s
+}
+
+// Destinations returns a key for each chat.
+func (s TelegramSerder[T]) Destinations() []string {
+	keys := make([]string, len(s.chats))
+
+	for index, chat := range s.chats {
+		keys[index] = telegramKey(chat.Recipient())
+	}
+
+	return keys
+}
+
+func telegramDelivery(chat telebot.Recipient, kind storage.DeliveryKind, sent *telebot.Message) storage.Delivery {
+	delivery := storage.Delivery{
+		Destination: telegramKey(chat.Recipient()),
+		Kind:        kind,
+		SentAt:      time.Now(),
+	}
+
+	if sent != nil {
+		delivery.MessageID = strconv.Itoa(sent.ID)
+	}
+
+	return delivery
+}
+
+// storeSent marks the entry as sent and records its deliveries, senders without storage skip it.
+func storeSent[T model.IEntry](store storage.Storage[T], entry T, deliveries []storage.Delivery) error {
+	if store == nil {
+		return nil
+	}
+
+	err := store.Store(storage.Entry[T]{
+		Data:   entry,
+		Status: storage.StatusSent,
+	})
+	if err != nil {
+		return err
+	}
+
+	return storeDeliveries(store, entry, deliveries)
+}
+
+// partialSent records the deliveries made before the failure, the error reports them to the multi sender.
+func partialSent[T model.IEntry](store storage.Storage[T], entry T, deliveries []storage.Delivery, err error) error {
+	if len(deliveries) == 0 {
+		return err
+	}
+
+	if storeErr := storeSent(store, entry, deliveries); storeErr != nil {
+		return storeErr
+	}
+
+	return DeliveryError{Deliveries: deliveries, Err: err}
+}
+
+func updateSent[T model.IEntry](store storage.Storage[T], entry T, deliveries []storage.Delivery) error {
+	if store == nil {
+		return nil
+	}
+
+	err := store.Update(storage.Entry[T]{
+		Data:   entry,
+		Status: storage.StatusSent,
+	})
+	if err != nil {
+		return err
+	}
+
+	return storeDeliveries(store, entry, deliveries)
+}
+
+func storeDeliveries[T model.IEntry](store storage.Storage[T], entry T, deliveries []storage.Delivery) error {
+	hash, err := entry.Hash()
+	if err != nil {
+		return err
+	}
+
+	for _, delivery := range deliveries {
+		delivery.Hash = hash
+
+		if err = store.StoreDelivery(delivery); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,177 @@
+package similarity
+
+import (
+	"net/url"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/vinicius73/gear-feed/pkg/model"
+	"github.com/vinicius73/gear-feed/pkg/support"
+)
+
+const (
+	DefaultThreshold = 0.5
+	// minShared avoids clustering short titles like "new trailer of X" and "new trailer of Y".
+	minShared = 3
+)
+
+// stopwords of the source languages, they do not identify a story.
+var stopwords = map[string]bool{
+	"a": true, "o": true, "as": true, "os": true, "de": true, "da": true, "do": true, "das": true, "dos": true,
+	"e": true, "em": true, "no": true, "na": true, "nos": true, "nas": true, "um": true, "uma": true,
+	"para": true, "pra": true, "por": true, "com": true, "sem": true, "que": true, "se": true, "ao": true,
+	"the": true, "an": true, "of": true, "and": true, "in": true, "on": true, "to": true, "for": true,
+	"with": true, "is": true, "are": true, "at": true, "by": true, "from": true, "its": true, "it": true,
+}
+
+// Tokens returns the normalized words of the title, without accents and stopwords.
+func Tokens(title string) []string {
+	normalized := support.Fold(title)
+
+	words := strings.FieldsFunc(normalized, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	tokens := []string{}
+
+	for _, word := range words {
+		// numbers identify sequels, like "gta 6"
+		if (len(word) > 1 || unicode.IsDigit(rune(word[0]))) && !stopwords[word] {
+			tokens = append(tokens, word)
+		}
+	}
+
+	return tokens
+}
+
+// Shingles returns the set of tokens sequences of the size.
+func Shingles(tokens []string, size int) map[string]bool {
+	shingles := map[string]bool{}
+
+	if len(tokens) < size {
+		size = len(tokens)
+	}
+
+	for index := 0; index+size <= len(tokens) && size > 0; index++ {
+		shingles[strings.Join(tokens[index:index+size], " ")] = true
+	}
+
+	return shingles
+}
+
+// Jaccard returns the similarity of the sets, between 0 and 1, and how many items they share.
+func Jaccard(a, b map[string]bool) (float64, int) {
+	if len(a) == 0 || len(b) == 0 {
+		return 0, 0
+	}
+
+	shared := 0
+
+	for item := range a {
+		if b[item] {
+			shared++
+		}
+	}
+
+	return float64(shared) / float64(len(a)+len(b)-shared), shared
+}
+
+// CanonicalURL is the hash key of the link, without the scheme and "www.".
+func CanonicalURL(link string) string {
+	key := model.HashKey(link)
+
+	parsed, err := url.Parse(key)
+	if err != nil || parsed.Host == "" {
+		return key
+	}
+
+	return strings.TrimPrefix(parsed.Host, "www.") + parsed.EscapedPath() + queryOf(parsed)
+}
+
+func queryOf(parsed *url.URL) string {
+	if parsed.RawQuery == "" {
+		return ""
+	}
+
+	return "?" + parsed.RawQuery
+}
+
+// Item is an entry that can be grouped with others.
+type Item struct {
+	// ID is not compared with itself, an entry is not a duplicate of its previous version.
+	ID        string
+	Cluster   string
+	Title     string
+	URL       string
+	Published time.Time
+}
+
+type member struct {
+	Item
+	url    string
+	tokens map[string]bool
+}
+
+// Clusters groups the items with the same canonical URL or similar titles, published within the window.
+type Clusters struct {
+	// Threshold is the minimal Jaccard similarity of the titles.
+	Threshold float64
+	// Window is the maximal distance of the published dates, zero ignores the dates.
+	Window  time.Duration
+	members []member
+}
+
+func NewClusters(threshold float64, window time.Duration) *Clusters {
+	if threshold <= 0 {
+		threshold = DefaultThreshold
+	}
+
+	return &Clusters{Threshold: threshold, Window: window, members: []member{}}
+}
+
+// Find returns the cluster of the item, an empty string means there is no similar item.
+func (c *Clusters) Find(item Item) string {
+	canonical := CanonicalURL(item.URL)
+	tokens := Shingles(Tokens(item.Title), 1)
+
+	for _, current := range c.members {
+		if current.ID == item.ID || !c.inWindow(current.Published, item.Published) {
+			continue
+		}
+
+		if canonical != "" && canonical == current.url {
+			return current.Cluster
+		}
+
+		similarity, shared := Jaccard(tokens, current.tokens)
+		if shared >= minShared && similarity >= c.Threshold {
+			return current.Cluster
+		}
+	}
+
+	return ""
+}
+
+// Add keeps the item, which must have a cluster, to be compared with the next ones.
+func (c *Clusters) Add(item Item) {
+	c.members = append(c.members, member{
+		Item:   item,
+		url:    CanonicalURL(item.URL),
+		tokens: Shingles(Tokens(item.Title), 1),
+	})
+}
+
+func (c *Clusters) inWindow(a, b time.Time) bool {
+	if c.Window <= 0 || a.IsZero() || b.IsZero() {
+		return true
+	}
+
+	distance := a.Sub(b)
+
+	if distance < 0 {
+		distance = -distance
+	}
+
+	return distance <= c.Window
+}
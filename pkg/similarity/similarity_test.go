@@ -0,0 +1,81 @@
+package similarity_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vinicius73/gear-feed/pkg/similarity"
+)
+
+func TestTokens(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t,
+		[]string{"nintendo", "anuncia", "novo", "trailer", "zelda"},
+		similarity.Tokens("Nintendo anuncia novo trailer de Zelda!"),
+	)
+	assert.Equal(t,
+		[]string{"gta", "6", "ganha", "data", "lancamento"},
+		similarity.Tokens("GTA 6 ganha data de lançamento"),
+	)
+}
+
+func TestCanonicalURL(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "foo.com/news/1", similarity.CanonicalURL("https://www.foo.com/news/1/?utm_source=feed#top"))
+	assert.Equal(t, "foo.com/news/1", similarity.CanonicalURL("http://FOO.com/news/1"))
+	assert.Equal(t, "/news/1", similarity.CanonicalURL("/news/1"))
+}
+
+func TestClustersFind(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	clusters := similarity.NewClusters(0, time.Hour*48)
+
+	clusters.Add(similarity.Item{
+		ID:        "a",
+		Cluster:   "zelda",
+		Title:     "Nintendo anuncia novo trailer de Zelda",
+		URL:       "https://ign.com/zelda",
+		Published: now,
+	})
+
+	tests := []struct {
+		name string
+		item similarity.Item
+		want string
+	}{
+		{
+			name: "similar title",
+			item: similarity.Item{ID: "b", Title: "Zelda ganha novo trailer", URL: "https://voxel.com/1", Published: now},
+			want: "zelda",
+		},
+		{
+			name: "same canonical url",
+			item: similarity.Item{ID: "c", Title: "Other", URL: "https://www.ign.com/zelda?utm_medium=home", Published: now},
+			want: "zelda",
+		},
+		{
+			name: "other game",
+			item: similarity.Item{ID: "d", Title: "Novo trailer de Mario", URL: "https://voxel.com/2", Published: now},
+			want: "",
+		},
+		{
+			name: "outside the window",
+			item: similarity.Item{ID: "e", Title: "Zelda ganha novo trailer", URL: "https://voxel.com/3", Published: now.Add(-time.Hour * 72)},
+			want: "",
+		},
+		{
+			name: "same entry",
+			item: similarity.Item{ID: "a", Title: "Nintendo anuncia novo trailer de Zelda", URL: "https://ign.com/zelda", Published: now},
+			want: "",
+		},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.want, clusters.Find(test.item), test.name)
+	}
+}
@@ -16,6 +16,19 @@ func (c Collection) OnlyStorieSuported() Collection {
 	return coll
 }
 
+// StripParams returns the strip_params of the sources by name.
+func (c Collection) StripParams() map[string][]string {
+	params := map[string][]string{}
+
+	for _, source := range c {
+		if len(source.StripParams) > 0 {
+			params[source.Name] = source.StripParams
+		}
+	}
+
+	return params
+}
+
 func (c Collection) Names() []string {
 	names := []string{}
 
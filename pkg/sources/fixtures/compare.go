@@ -0,0 +1,70 @@
+package fixtures
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vinicius73/gear-feed/pkg/model"
+)
+
+// Compare lists the differences between the golden and the extracted entries, matched by link.
+// Entries sharing the same link are matched in order.
+func Compare(golden, entries []model.Entry) []string {
+	diff := []string{}
+	found := map[string][]model.Entry{}
+
+	for _, entry := range sortEntries(entries) {
+		found[entry.URL] = append(found[entry.URL], entry)
+	}
+
+	for _, want := range sortEntries(golden) {
+		candidates := found[want.URL]
+
+		if len(candidates) == 0 {
+			diff = append(diff, "- "+want.URL)
+
+			continue
+		}
+
+		found[want.URL] = candidates[1:]
+
+		for _, field := range compareEntry(want, candidates[0]) {
+			diff = append(diff, "~ "+want.URL+" "+field)
+		}
+	}
+
+	for _, entry := range sortEntries(entries) {
+		if candidates := found[entry.URL]; len(candidates) > 0 {
+			diff = append(diff, "+ "+entry.URL)
+			found[entry.URL] = candidates[1:]
+		}
+	}
+
+	return diff
+}
+
+func compareEntry(want, got model.Entry) []string {
+	fields := []string{}
+
+	if want.Title != got.Title {
+		fields = append(fields, fmt.Sprintf("title: %q != %q", want.Title, got.Title))
+	}
+
+	if want.Image != got.Image {
+		fields = append(fields, fmt.Sprintf("image: %q != %q", want.Image, got.Image))
+	}
+
+	if want.SourceName != got.SourceName {
+		fields = append(fields, fmt.Sprintf("source: %q != %q", want.SourceName, got.SourceName))
+	}
+
+	if !want.PublishedAt.Equal(got.PublishedAt) {
+		fields = append(fields, fmt.Sprintf("published: %s != %s", want.PublishedAt, got.PublishedAt))
+	}
+
+	if strings.Join(want.Categories, ",") != strings.Join(got.Categories, ",") {
+		fields = append(fields, fmt.Sprintf("categories: %q != %q", want.Categories, got.Categories))
+	}
+
+	return fields
+}
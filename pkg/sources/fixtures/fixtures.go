@@ -0,0 +1,158 @@
+package fixtures
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/vinicius73/gear-feed/pkg/model"
+	"github.com/vinicius73/gear-feed/pkg/scraper"
+	"github.com/vinicius73/gear-feed/pkg/support/apperrors"
+)
+
+const (
+	indexFile    = "index.json"
+	goldenFile   = "golden.json"
+	responsesDir = "responses"
+	filePerm     = 0o644
+	dirPerm      = 0o755
+)
+
+var (
+	ErrFixtureNotFound = apperrors.NotFound("fixture", "FIXTURES:NOT_FOUND")
+	ErrMissingResponse = apperrors.Business("no response recorded for %s", "FIXTURES:MISSING_RESPONSE")
+	ErrFailToWrite     = apperrors.System(nil, "fail to write fixtures", "FIXTURES:FAIL_TO_WRITE")
+	ErrFailToRead      = apperrors.System(nil, "fail to read fixtures", "FIXTURES:FAIL_TO_READ")
+)
+
+// index maps the requested URLs to the response files.
+type index map[string]string
+
+// Snapshot fetches the source, saves the responses and the extracted entries as the golden file.
+func Snapshot(ctx context.Context, source scraper.SourceDefinition, dir string) ([]model.Entry, error) {
+	transport, closeTransport := scraper.Transport(source)
+
+	defer closeTransport()
+
+	recorder := newRecorder(transport)
+
+	entries, err := scraper.FindEntries[model.Entry](scraper.WithTransport(ctx, recorder), source)
+	if err != nil {
+		return entries, err
+	}
+
+	dir = filepath.Join(dir, source.Name)
+
+	if err = recorder.save(dir); err != nil {
+		return entries, ErrFailToWrite.Wrap(err)
+	}
+
+	return entries, WriteGolden(dir, entries)
+}
+
+// Replay extracts the entries of the source from the saved responses, without network access.
+func Replay(ctx context.Context, source scraper.SourceDefinition, dir string) ([]model.Entry, error) {
+	dir = filepath.Join(dir, source.Name)
+
+	player, err := newPlayer(dir)
+	if err != nil {
+		return []model.Entry{}, err
+	}
+
+	ctx = scraper.WithoutDelay(scraper.WithTransport(ctx, player))
+
+	return scraper.FindEntries[model.Entry](ctx, source)
+}
+
+// Test replays the source fixtures and compares the entries with the golden file.
+func Test(ctx context.Context, source scraper.SourceDefinition, dir string) ([]string, error) {
+	golden, err := ReadGolden(filepath.Join(dir, source.Name))
+	if err != nil {
+		return []string{}, err
+	}
+
+	entries, err := Replay(ctx, source, dir)
+	if err != nil {
+		return []string{}, err
+	}
+
+	return Compare(golden, entries), nil
+}
+
+// Sources returns the names of the sources with fixtures in the directory.
+func Sources(dir string) ([]string, error) {
+	names := []string{}
+
+	dirEntries, err := os.ReadDir(dir)
+	if err != nil {
+		return names, ErrFailToRead.Wrap(err)
+	}
+
+	for _, entry := range dirEntries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		if _, err := os.Stat(filepath.Join(dir, entry.Name(), indexFile)); err == nil {
+			names = append(names, entry.Name())
+		}
+	}
+
+	return names, nil
+}
+
+// WriteGolden saves the sorted entries, so the file is stable between snapshots.
+func WriteGolden(dir string, entries []model.Entry) error {
+	entries = sortEntries(entries)
+
+	content, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return ErrFailToWrite.Wrap(err)
+	}
+
+	if err = os.MkdirAll(dir, dirPerm); err != nil {
+		return ErrFailToWrite.Wrap(err)
+	}
+
+	if err = os.WriteFile(filepath.Join(dir, goldenFile), append(content, '\n'), filePerm); err != nil {
+		return ErrFailToWrite.Wrap(err)
+	}
+
+	return nil
+}
+
+func ReadGolden(dir string) ([]model.Entry, error) {
+	entries := []model.Entry{}
+
+	content, err := os.ReadFile(filepath.Join(dir, goldenFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return entries, ErrFixtureNotFound
+	}
+
+	if err != nil {
+		return entries, ErrFailToRead.Wrap(err)
+	}
+
+	if err = json.Unmarshal(content, &entries); err != nil {
+		return entries, ErrFailToRead.Wrap(err)
+	}
+
+	return entries, nil
+}
+
+func sortEntries(entries []model.Entry) []model.Entry {
+	sorted := append([]model.Entry{}, entries...)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].URL != sorted[j].URL {
+			return sorted[i].URL < sorted[j].URL
+		}
+
+		return sorted[i].Title < sorted[j].Title
+	})
+
+	return sorted
+}
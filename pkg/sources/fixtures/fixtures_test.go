@@ -0,0 +1,148 @@
+package fixtures_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vinicius73/gear-feed/pkg/model"
+	"github.com/vinicius73/gear-feed/pkg/scraper"
+	"github.com/vinicius73/gear-feed/pkg/scraper/testdata"
+	"github.com/vinicius73/gear-feed/pkg/sources/fixtures"
+	"github.com/vinicius73/gear-feed/pkg/support/apperrors"
+)
+
+const source = `
+name: FIXTURE
+enabled: true
+paths:
+ - /example_01.html
+attributes:
+	entry_selector: "#news > article"
+	link:
+		path: "h2 a"
+		attribute: "href"
+	title:
+		path: "h2 a"
+	image:
+		path: "figure img"
+		attribute: "src"
+`
+
+func TestSnapshotAndReplay(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	server := httptest.NewServer(testdata.FileHandler())
+
+	definition, err := testdata.ParseSource(server.URL, source)
+	require.NoError(t, err)
+
+	entries, err := fixtures.Snapshot(scraper.WithoutDelay(context.TODO()), definition, dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 3)
+	assert.FileExists(t, filepath.Join(dir, "FIXTURE", "golden.json"))
+
+	names, err := fixtures.Sources(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"FIXTURE"}, names)
+
+	// the replay must not touch the network
+	server.Close()
+
+	diff, err := fixtures.Test(context.TODO(), definition, dir)
+	require.NoError(t, err)
+	assert.Empty(t, diff)
+
+	definition.Attributes.Title.Path = "h2"
+	definition.Attributes.Link.Path = "figure"
+
+	diff, err = fixtures.Test(context.TODO(), definition, dir)
+	require.NoError(t, err)
+	assert.NotEmpty(t, diff)
+
+	definition.Paths = []string{"/example_02.html"}
+
+	_, err = fixtures.Replay(context.TODO(), definition, dir)
+	assert.ErrorContains(t, err, "FIXTURES:MISSING_RESPONSE")
+}
+
+func TestSnapshotAndReplayPaths(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	server := httptest.NewServer(testdata.FileHandler())
+
+	definition, err := testdata.ParseSource(server.URL, source)
+	require.NoError(t, err)
+
+	// the limit is reached by the first path, the second one is not recorded
+	definition.Paths = []string{"/example_01.html", "/example_02.html"}
+	definition.Limit = 3
+
+	entries, err := fixtures.Snapshot(scraper.WithoutDelay(context.TODO()), definition, dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 3)
+
+	server.Close()
+
+	// the replays request the paths in the recorded order
+	for range 10 {
+		diff, err := fixtures.Test(context.TODO(), definition, dir)
+		require.NoError(t, err)
+		assert.Empty(t, diff)
+	}
+}
+
+func TestReplayWithoutFixtures(t *testing.T) {
+	t.Parallel()
+
+	definition, err := testdata.ParseSource("http://localhost", source)
+	require.NoError(t, err)
+
+	_, err = fixtures.Replay(context.TODO(), definition, t.TempDir())
+	assert.Equal(t, "FIXTURES:NOT_FOUND", apperrors.Code(err))
+}
+
+func TestCompare(t *testing.T) {
+	t.Parallel()
+
+	golden := []model.Entry{
+		{Title: "First", URL: "http://foo.com/1", SourceName: "FOO"},
+		{Title: "Second", URL: "http://foo.com/2", SourceName: "FOO"},
+		{Title: "Same link", URL: "http://foo.com/3", SourceName: "FOO"},
+	}
+
+	assert.Empty(t, fixtures.Compare(golden, golden))
+
+	entries := []model.Entry{
+		{Title: "Same link", URL: "http://foo.com/3", SourceName: "FOO"},
+		{Title: "Same link", URL: "http://foo.com/3", SourceName: "FOO"},
+		{Title: "First!", URL: "http://foo.com/1", SourceName: "FOO"},
+	}
+
+	assert.Equal(t, []string{
+		`~ http://foo.com/1 title: "First" != "First!"`,
+		"- http://foo.com/2",
+		"+ http://foo.com/3",
+	}, fixtures.Compare(golden, entries))
+}
+
+func TestWriteGolden(t *testing.T) {
+	t.Parallel()
+
+	dir := filepath.Join(t.TempDir(), "FOO")
+
+	require.NoError(t, fixtures.WriteGolden(dir, []model.Entry{
+		{Title: "B", URL: "http://foo.com/b"},
+		{Title: "A", URL: "http://foo.com/a"},
+	}))
+
+	entries, err := fixtures.ReadGolden(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "A", entries[0].Title)
+}
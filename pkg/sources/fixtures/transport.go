@@ -0,0 +1,141 @@
+package fixtures
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// recorder keeps the responses received by the scraper.
+type recorder struct {
+	base      http.RoundTripper
+	mu        sync.Mutex
+	urls      []string
+	responses map[string][]byte
+}
+
+func newRecorder(base http.RoundTripper) *recorder {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &recorder{
+		base:      base,
+		mu:        sync.Mutex{},
+		urls:      []string{},
+		responses: map[string][]byte{},
+	}
+}
+
+func (r *recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	res, err := r.base.RoundTrip(req)
+	if err != nil {
+		return res, err
+	}
+
+	// DumpResponse restores the body, so the scraper can still read it
+	dump, err := httputil.DumpResponse(res, true)
+	if err != nil {
+		return res, err
+	}
+
+	url := req.URL.String()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, found := r.responses[url]; !found {
+		r.urls = append(r.urls, url)
+	}
+
+	r.responses[url] = dump
+
+	return res, nil
+}
+
+// save replaces the responses of the fixture directory.
+func (r *recorder) save(dir string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	responses := filepath.Join(dir, responsesDir)
+
+	if err := os.RemoveAll(responses); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(responses, dirPerm); err != nil {
+		return err
+	}
+
+	files := index{}
+
+	for position, url := range r.urls {
+		name := fmt.Sprintf("%03d.http", position+1)
+
+		if err := os.WriteFile(filepath.Join(responses, name), r.responses[url], filePerm); err != nil {
+			return err
+		}
+
+		files[url] = filepath.Join(responsesDir, name)
+	}
+
+	content, err := json.MarshalIndent(files, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(dir, indexFile), append(content, '\n'), filePerm)
+}
+
+// player answers the requests with the saved responses.
+type player struct {
+	dir   string
+	files index
+}
+
+func newPlayer(dir string) (player, error) {
+	files := index{}
+
+	content, err := os.ReadFile(filepath.Join(dir, indexFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return player{dir: dir, files: files}, ErrFixtureNotFound
+	}
+
+	if err != nil {
+		return player{dir: dir, files: files}, ErrFailToRead.Wrap(err)
+	}
+
+	if err = json.Unmarshal(content, &files); err != nil {
+		return player{dir: dir, files: files}, ErrFailToRead.Wrap(err)
+	}
+
+	return player{dir: dir, files: files}, nil
+}
+
+func (p player) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		_, _ = io.Copy(io.Discard, req.Body)
+		req.Body.Close()
+	}
+
+	file, found := p.files[req.URL.String()]
+	if !found {
+		return nil, ErrMissingResponse.Msgf(req.URL.String())
+	}
+
+	content, err := os.ReadFile(filepath.Join(p.dir, file))
+	if err != nil {
+		return nil, ErrFailToRead.Wrap(err)
+	}
+
+	return http.ReadResponse(bufio.NewReader(bytes.NewReader(content)), req)
+}
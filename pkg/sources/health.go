@@ -0,0 +1,139 @@
+package sources
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/vinicius73/gear-feed/pkg/model"
+	"github.com/vinicius73/gear-feed/pkg/scraper"
+	"github.com/vinicius73/gear-feed/pkg/storage"
+)
+
+const (
+	defaultMaxFailures = 5
+	defaultQuarantine  = time.Hour * 12
+)
+
+type HealthConfig struct {
+	// MaxFailures is the number of consecutive failures before quarantining a source,
+	// a negative value disables the quarantine.
+	MaxFailures int `fig:"max_failures" yaml:"max_failures"`
+	// Quarantine is the period a failing source is skipped.
+	Quarantine time.Duration `fig:"quarantine" yaml:"quarantine"`
+}
+
+// Record applies the outcome of a scrape to the source health.
+func (c HealthConfig) Record(health storage.SourceHealth, entries int, err error, now time.Time) storage.SourceHealth {
+	health.UpdatedAt = now
+
+	if err == nil {
+		health.LastSuccess = now
+		health.ConsecutiveFailures = 0
+		health.QuarantinedUntil = time.Time{}
+
+		if entries == 0 {
+			health.ZeroEntryRuns++
+		} else {
+			health.ZeroEntryRuns = 0
+		}
+
+		return health
+	}
+
+	health.LastFailure = now
+	health.LastError = err.Error()
+	health.ConsecutiveFailures++
+
+	if errors.Is(err, scraper.ErrCloudflareChallenge) {
+		health.CloudflareHits++
+	}
+
+	// after the quarantine, a single failure is enough to quarantine it again
+	if maxFailures := c.maxFailures(); maxFailures > 0 && health.ConsecutiveFailures >= maxFailures {
+		health.QuarantinedUntil = now.Add(c.quarantine())
+	}
+
+	return health
+}
+
+func (c HealthConfig) maxFailures() int {
+	if c.MaxFailures == 0 {
+		return defaultMaxFailures
+	}
+
+	return c.MaxFailures
+}
+
+func (c HealthConfig) quarantine() time.Duration {
+	if c.Quarantine <= 0 {
+		return defaultQuarantine
+	}
+
+	return c.Quarantine
+}
+
+// Tracker persists the health of the sources.
+type Tracker[T model.IEntry] struct {
+	store  storage.Storage[T]
+	config HealthConfig
+	mu     *sync.Mutex
+}
+
+func NewTracker[T model.IEntry](store storage.Storage[T], config HealthConfig) Tracker[T] {
+	return Tracker[T]{
+		store:  store,
+		config: config,
+		mu:     &sync.Mutex{},
+	}
+}
+
+// Available removes the quarantined sources, which are also returned.
+func (t Tracker[T]) Available(list Collection) (Collection, []storage.SourceHealth, error) {
+	healths, err := t.store.SourcesHealth(list.Names()...)
+	if err != nil {
+		return list, nil, err
+	}
+
+	now := time.Now()
+	quarantined := []storage.SourceHealth{}
+	skip := map[string]bool{}
+
+	for _, health := range healths {
+		if health.Quarantined(now) {
+			quarantined = append(quarantined, health)
+			skip[health.Source] = true
+		}
+	}
+
+	available := Collection{}
+
+	for _, source := range list {
+		if !skip[source.Name] {
+			available = append(available, source)
+		}
+	}
+
+	return available, quarantined, nil
+}
+
+// Record the outcome of a scrape, it is safe to be called by concurrent workers.
+func (t Tracker[T]) Record(source string, entries int, err error) (storage.SourceHealth, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	health := storage.SourceHealth{Source: source}
+
+	found, findErr := t.store.SourcesHealth(source)
+	if findErr != nil {
+		return health, findErr
+	}
+
+	if len(found) > 0 {
+		health = found[0]
+	}
+
+	health = t.config.Record(health, entries, err, time.Now())
+
+	return health, t.store.StoreSourceHealth(health)
+}
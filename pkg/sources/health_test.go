@@ -0,0 +1,58 @@
+package sources_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vinicius73/gear-feed/pkg/scraper"
+	"github.com/vinicius73/gear-feed/pkg/sources"
+	"github.com/vinicius73/gear-feed/pkg/storage"
+)
+
+func TestHealthConfigRecord(t *testing.T) {
+	t.Parallel()
+
+	config := sources.HealthConfig{MaxFailures: 2, Quarantine: time.Hour}
+	now := time.Now()
+	health := storage.SourceHealth{Source: "alpha"}
+
+	health = config.Record(health, 0, nil, now)
+	assert.Equal(t, 1, health.ZeroEntryRuns)
+	assert.Equal(t, now, health.LastSuccess)
+
+	health = config.Record(health, 0, fmt.Errorf("%w: http://alpha", scraper.ErrCloudflareChallenge), now)
+	assert.Equal(t, 1, health.ConsecutiveFailures)
+	assert.Equal(t, 1, health.CloudflareHits)
+	assert.Equal(t, 1, health.ZeroEntryRuns)
+	assert.False(t, health.Quarantined(now))
+
+	health = config.Record(health, 0, errors.New("timeout"), now)
+	assert.Equal(t, 2, health.ConsecutiveFailures)
+	assert.Equal(t, 1, health.CloudflareHits)
+	assert.Equal(t, "timeout", health.LastError)
+	assert.True(t, health.Quarantined(now))
+	assert.False(t, health.Quarantined(now.Add(time.Hour)))
+
+	health = config.Record(health, 3, nil, now)
+	assert.Zero(t, health.ConsecutiveFailures)
+	assert.Zero(t, health.ZeroEntryRuns)
+	assert.False(t, health.Quarantined(now))
+}
+
+func TestHealthConfigRecordDisabled(t *testing.T) {
+	t.Parallel()
+
+	config := sources.HealthConfig{MaxFailures: -1, Quarantine: 0}
+	now := time.Now()
+	health := storage.SourceHealth{Source: "alpha"}
+
+	for range 10 {
+		health = config.Record(health, 0, errors.New("timeout"), now)
+	}
+
+	assert.Equal(t, 10, health.ConsecutiveFailures)
+	assert.False(t, health.Quarantined(now))
+}
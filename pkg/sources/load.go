@@ -40,10 +40,40 @@ func Load(ctx context.Context, opt LoadOptions) (Collection, error) {
 func loadPath(ctx context.Context, path string, only []string) (Collection, error) {
 	definitions := []scraper.SourceDefinition{}
 
+	files, err := listFiles(ctx, path)
+	if err != nil {
+		return definitions, err
+	}
+
+	logger := zerolog.Ctx(ctx).With().Str("path", path).Logger()
+
+	for _, file := range files {
+		def, use, err := readFile(file, only)
+		if err != nil {
+			logger.Error().Err(err).Msgf("Fail to read file %s", filepath.Base(file))
+
+			return definitions, ErrFailToLoad.Wrap(err)
+		}
+
+		if use {
+			definitions = append(definitions, def)
+		} else {
+			logger.Debug().
+				Msgf("Ignoring %s", def.Name)
+		}
+	}
+
+	return definitions, nil
+}
+
+// listFiles returns the yaml files of the directory.
+func listFiles(ctx context.Context, path string) ([]string, error) {
+	files := []string{}
+
 	if !filepath.IsAbs(path) {
 		pwd, err := os.Getwd()
 		if err != nil {
-			return definitions, ErrFailToLoad.Wrap(err)
+			return files, ErrFailToLoad.Wrap(err)
 		}
 
 		path = filepath.Join(pwd, path)
@@ -51,7 +81,7 @@ func loadPath(ctx context.Context, path string, only []string) (Collection, erro
 
 	dirEntries, err := os.ReadDir(path)
 	if err != nil {
-		return definitions, err
+		return files, err
 	}
 
 	logger := zerolog.Ctx(ctx).With().Str("path", path).Logger()
@@ -79,22 +109,10 @@ func loadPath(ctx context.Context, path string, only []string) (Collection, erro
 			continue
 		}
 
-		def, use, err := readFile(filepath.Join(path, name), only)
-		if err != nil {
-			logger.Error().Err(err).Msgf("Fail to read file %s", name)
-
-			return definitions, ErrFailToLoad.Wrap(err)
-		}
-
-		if use {
-			definitions = append(definitions, def)
-		} else {
-			logger.Debug().
-				Msgf("Ignoring %s", def.Name)
-		}
+		files = append(files, filepath.Join(path, name))
 	}
 
-	return definitions, nil
+	return files, nil
 }
 
 func readFile(fileName string, only []string) (scraper.SourceDefinition, bool, error) {
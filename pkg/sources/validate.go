@@ -0,0 +1,404 @@
+package sources
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/andybalholm/cascadia"
+	"github.com/antchfx/xpath"
+	"github.com/vinicius73/gear-feed/pkg/filters"
+	"github.com/vinicius73/gear-feed/pkg/scraper"
+	"github.com/vinicius73/gear-feed/pkg/support"
+	"github.com/vinicius73/gear-feed/pkg/support/apperrors"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	ErrInvalidSources = apperrors.Business("%d problems found in the source definitions", "SOURCES:INVALID")
+	// ErrNoSources is returned when the paths have no yaml files, their subdirectories are not read.
+	ErrNoSources = apperrors.Business("no source definitions found in %s", "SOURCES:NOT_FOUND")
+)
+
+var reLine = regexp.MustCompile(`line (\d+): (.*)`)
+
+var knownParsers = []string{scraper.HTML, scraper.XML, scraper.JSON, scraper.RSS, scraper.ATOM}
+
+// Problem found in a source definition file.
+type Problem struct {
+	File    string
+	Line    int
+	Column  int
+	Message string
+}
+
+func (p Problem) String() string {
+	position := p.File + ":" + strconv.Itoa(p.Line)
+
+	if p.Column > 0 {
+		position += ":" + strconv.Itoa(p.Column)
+	}
+
+	return position + ": " + p.Message
+}
+
+type fileValidator struct {
+	file     string
+	root     *yaml.Node
+	problems []Problem
+}
+
+type definedName struct {
+	file string
+	line int
+}
+
+// Validate strictly decodes all the source definitions of the paths, enabled or not.
+func Validate(ctx context.Context, paths []string) ([]Problem, error) {
+	problems := []Problem{}
+	names := map[string]definedName{}
+
+	checked := 0
+
+	for _, path := range paths {
+		files, err := listFiles(ctx, path)
+		if err != nil {
+			return problems, ErrFailToLoad.Wrap(err)
+		}
+
+		checked += len(files)
+
+		for _, file := range files {
+			content, err := os.ReadFile(file)
+			if err != nil {
+				return problems, ErrFailToLoad.Wrap(err)
+			}
+
+			def, validator := validateFile(displayPath(file), content)
+
+			if def.Name != "" {
+				line, column := validator.position("name")
+
+				if first, found := names[def.Name]; found {
+					validator.addAt(line, column, fmt.Sprintf("duplicate name %q, already defined in %s:%d", def.Name, first.file, first.line))
+				} else {
+					names[def.Name] = definedName{file: validator.file, line: line}
+				}
+			}
+
+			problems = append(problems, validator.problems...)
+		}
+	}
+
+	if checked == 0 {
+		return problems, ErrNoSources.Msgf(strings.Join(paths, ", "))
+	}
+
+	return problems, nil
+}
+
+// ValidateDefinition checks a single source definition file content.
+func ValidateDefinition(file string, content []byte) []Problem {
+	_, validator := validateFile(file, content)
+
+	return validator.problems
+}
+
+func validateFile(file string, content []byte) (scraper.SourceDefinition, *fileValidator) {
+	var def scraper.SourceDefinition
+
+	validator := &fileValidator{file: file, root: nil, problems: []Problem{}}
+
+	var root yaml.Node
+
+	if err := yaml.Unmarshal(content, &root); err != nil {
+		validator.addError(err)
+
+		return def, validator
+	}
+
+	validator.root = &root
+
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+
+	if err := decoder.Decode(&def); err != nil {
+		validator.addError(err)
+	}
+
+	validator.check(def)
+
+	return def, validator
+}
+
+//nolint:cyclop
+func (v *fileValidator) check(def scraper.SourceDefinition) {
+	parser := strings.ToUpper(def.Parser)
+	feed := parser == scraper.RSS || parser == scraper.ATOM
+	attributes := def.Attributes
+
+	if strings.TrimSpace(def.Name) == "" {
+		v.add("name is required", "name")
+	}
+
+	if len(def.Paths) == 0 {
+		v.add("at least one path is required", "paths")
+	}
+
+	if def.BaseURL == "" && !allAbsolute(def.Paths) {
+		v.add("base_url is required for relative paths", "base_url")
+	}
+
+	if def.Limit < 0 {
+		v.add("limit must not be negative", "limit")
+	}
+
+	if def.Weight < 0 {
+		v.add("weight must not be negative", "weight")
+	}
+
+	if parser != "" && !support.Contains(knownParsers, parser) {
+		v.add(fmt.Sprintf("unknown parser %q, expected one of %s", def.Parser, strings.Join(knownParsers, ", ")), "parser")
+	}
+
+	fetcher := strings.ToLower(def.Fetcher)
+
+	if fetcher != "" && fetcher != scraper.FetcherHTTP && fetcher != scraper.FetcherChrome {
+		v.add(fmt.Sprintf("unknown fetcher %q, expected %q, %q or empty", def.Fetcher, scraper.FetcherHTTP, scraper.FetcherChrome), "fetcher")
+	}
+
+	if fetcher == scraper.FetcherChrome {
+		if parser != "" && parser != scraper.HTML {
+			v.add("the chrome fetcher only supports the HTML parser", "fetcher")
+		}
+
+		v.selector(scraper.HTML, def.WaitFor, "wait_for")
+	}
+
+	v.pagination(parser, def.Pagination)
+
+	if def.Enrich.Concurrency < 0 {
+		v.add("concurrency must not be negative", "enrich", "concurrency")
+	}
+
+	v.filters(def.Filters.Include, "filters", "include")
+	v.filters(def.Filters.Exclude, "filters", "exclude")
+
+	if attributes.EntrySelector == "" && !feed {
+		v.add("entry_selector is required", "attributes", "entry_selector")
+	}
+
+	if !feed {
+		v.required(attributes.Link, "attributes", "link")
+		v.required(attributes.Title, "attributes", "title")
+	}
+
+	v.selector(parser, attributes.EntrySelector, "attributes", "entry_selector")
+
+	v.finder(parser, attributes.Link, "attributes", "link")
+	v.finder(parser, attributes.Title, "attributes", "title")
+	v.finder(parser, attributes.Image, "attributes", "image")
+	v.finder(parser, attributes.Category.PathFinder, "attributes", "category", "path_finder")
+	v.finder(parser, attributes.Published.PathFinder, "attributes", "published", "path_finder")
+}
+
+func (v *fileValidator) pagination(parser string, pagination scraper.Pagination) {
+	if pagination.MaxPages < 0 {
+		v.add("max_pages must not be negative", "pagination", "max_pages")
+	}
+
+	v.selector(parser, pagination.Next, "pagination", "next")
+
+	if pagination.Cursor != "" {
+		if parser != scraper.JSON {
+			v.add("cursor is only supported by the JSON parser", "pagination", "cursor")
+		}
+
+		if !strings.Contains(pagination.Template, "{cursor}") {
+			v.add("cursor requires a template with {cursor}", "pagination", "template")
+		}
+
+		v.selector(scraper.JSON, pagination.Cursor, "pagination", "cursor")
+	}
+
+	if pagination.Template != "" && !strings.Contains(pagination.Template, "{page}") && !strings.Contains(pagination.Template, "{cursor}") {
+		v.add("template requires {page} or {cursor}", "pagination", "template")
+	}
+}
+
+func (v *fileValidator) filters(rules []filters.Rule, keys ...string) {
+	var invalid apperrors.BusinessError
+
+	for _, rule := range rules {
+		if err := rule.Validate(); errors.As(err, &invalid) {
+			v.add(invalid.Message, keys...)
+		}
+	}
+}
+
+func (v *fileValidator) required(finder scraper.PathFinder, keys ...string) {
+	if finder.Path == "" && finder.Attribute == "" {
+		v.add(strings.Join(keys[1:], ".")+" requires a path or an attribute", keys...)
+	}
+}
+
+func (v *fileValidator) finder(parser string, finder scraper.PathFinder, keys ...string) {
+	if finder.ParseStrategy != scraper.ParserStrategyNone && finder.ParseStrategy != scraper.ParserStrategyStyle {
+		v.add(
+			fmt.Sprintf("unknown parse_strategy %q, expected %q or empty", finder.ParseStrategy, scraper.ParserStrategyStyle),
+			append(keys, "parse_strategy")...,
+		)
+	}
+
+	v.selector(parser, finder.Path, append(keys, "path")...)
+}
+
+func (v *fileValidator) selector(parser, selector string, keys ...string) {
+	if selector == "" {
+		return
+	}
+
+	var err error
+
+	switch parser {
+	case scraper.JSON:
+		err = checkJSONPath(selector)
+	case scraper.XML, scraper.RSS, scraper.ATOM:
+		_, err = xpath.Compile(selector)
+	case scraper.HTML, "":
+		_, err = cascadia.Compile(selector)
+	default:
+		// unknown parsers are already reported
+		return
+	}
+
+	if err != nil {
+		v.add(fmt.Sprintf("invalid selector %q: %s", selector, err), keys...)
+	}
+}
+
+func (v *fileValidator) add(message string, keys ...string) {
+	line, column := v.position(keys...)
+
+	v.addAt(line, column, message)
+}
+
+func (v *fileValidator) addAt(line, column int, message string) {
+	v.problems = append(v.problems, Problem{File: v.file, Line: line, Column: column, Message: message})
+}
+
+func (v *fileValidator) addError(err error) {
+	var typeErr *yaml.TypeError
+
+	messages := []string{err.Error()}
+
+	if errors.As(err, &typeErr) {
+		messages = typeErr.Errors
+	}
+
+	for _, message := range messages {
+		line := 0
+
+		if match := reLine.FindStringSubmatch(message); match != nil {
+			line, _ = strconv.Atoi(match[1])
+			message = match[2]
+		}
+
+		v.addAt(line, 0, message)
+	}
+}
+
+// position of the deepest existing node of the keys path.
+func (v *fileValidator) position(keys ...string) (int, int) {
+	if v.root == nil || len(v.root.Content) == 0 {
+		return 0, 0
+	}
+
+	node := v.root.Content[0]
+	line, column := node.Line, node.Column
+
+	for _, key := range keys {
+		value := mappingValue(node, key)
+		if value == nil {
+			break
+		}
+
+		node = value
+		line, column = node.Line, node.Column
+	}
+
+	return line, column
+}
+
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for index := 0; index+1 < len(node.Content); index += 2 {
+		if node.Content[index].Value == key {
+			return node.Content[index+1]
+		}
+	}
+
+	return nil
+}
+
+// checkJSONPath only checks the balance of the gjson path, which has no parser error.
+func checkJSONPath(path string) error {
+	pairs := map[rune]rune{']': '[', '}': '{', ')': '('}
+	stack := []rune{}
+	escaped := false
+
+	for _, char := range path {
+		switch {
+		case escaped:
+			escaped = false
+		case char == '\\':
+			escaped = true
+		case char == '[' || char == '{' || char == '(':
+			stack = append(stack, char)
+		case pairs[char] != 0:
+			if len(stack) == 0 || stack[len(stack)-1] != pairs[char] {
+				return fmt.Errorf("unexpected %q", char)
+			}
+
+			stack = stack[:len(stack)-1]
+		}
+	}
+
+	if len(stack) > 0 {
+		return fmt.Errorf("unclosed %q", stack[len(stack)-1])
+	}
+
+	return nil
+}
+
+func allAbsolute(paths []string) bool {
+	for _, path := range paths {
+		if !strings.HasPrefix(path, "http") && !strings.HasPrefix(path, "//") {
+			return false
+		}
+	}
+
+	return true
+}
+
+func displayPath(file string) string {
+	pwd, err := os.Getwd()
+	if err != nil {
+		return file
+	}
+
+	if rel, err := filepath.Rel(pwd, file); err == nil && !strings.HasPrefix(rel, "..") {
+		return rel
+	}
+
+	return file
+}
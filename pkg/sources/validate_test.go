@@ -0,0 +1,273 @@
+package sources_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vinicius73/gear-feed/pkg/sources"
+)
+
+const validSource = `name: VALID
+enabled: true
+base_url: https://example.com
+paths:
+  - /news
+parser: HTML
+attributes:
+  entry_selector: article.post
+  link:
+    path: a.title
+    attribute: href
+  title:
+    path: a.title
+  image:
+    path: .cover
+    attribute: style
+    parse_strategy: style
+`
+
+func messages(problems []sources.Problem) []string {
+	result := make([]string, len(problems))
+
+	for index, problem := range problems {
+		result[index] = problem.String()
+	}
+
+	return result
+}
+
+func TestValidateDefinition(t *testing.T) {
+	t.Parallel()
+
+	assert.Empty(t, sources.ValidateDefinition("valid.yml", []byte(validSource)))
+
+	problems := sources.ValidateDefinition("invalid.yml", []byte(`name: INVALID
+base_url: https://example.com
+paths: [/news]
+parser: HTLM
+color: red
+attributes:
+  entry_selector: ""
+  link:
+    path: a.title
+    parse_strategy: styles
+  title:
+    path: .title
+`))
+
+	assert.Equal(t, []string{
+		"invalid.yml:5: field color not found in type scraper.SourceDefinition",
+		`invalid.yml:4:9: unknown parser "HTLM", expected one of HTML, XML, JSON, RSS, ATOM`,
+		"invalid.yml:7:19: entry_selector is required",
+		`invalid.yml:10:21: unknown parse_strategy "styles", expected "style" or empty`,
+	}, messages(problems))
+}
+
+func TestValidateDefinitionWeight(t *testing.T) {
+	t.Parallel()
+
+	problems := sources.ValidateDefinition("weight.yml", []byte(`name: WEIGHT
+base_url: https://example.com
+paths: [/news]
+weight: -1
+attributes:
+  entry_selector: article
+  link: {path: a, attribute: href}
+  title: {path: h2}
+`))
+
+	assert.Equal(t, []string{"weight.yml:4:9: weight must not be negative"}, messages(problems))
+}
+
+func TestValidateDefinitionSelectors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name: "css",
+			content: `name: CSS
+base_url: https://example.com
+paths: [/news]
+parser: HTML
+attributes:
+  entry_selector: "article[class"
+  link: {path: a, attribute: href}
+  title: {path: h2}
+`,
+			want: `css.yml:6:19: invalid selector "article[class": `,
+		},
+		{
+			name: "xpath",
+			content: `name: XPATH
+base_url: https://example.com
+paths: [/feed]
+parser: RSS
+attributes:
+  title: {path: "/title[", attribute: ""}
+`,
+			want: `xpath.yml:6:17: invalid selector "/title[": `,
+		},
+		{
+			name: "json",
+			content: `name: JSON
+paths: [https://example.com/api]
+parser: JSON
+attributes:
+  entry_selector: "data.#(type==\"post\""
+  link: {path: url}
+  title: {path: title}
+`,
+			want: `json.yml:5:19: invalid selector "data.#(type==\"post\"": unclosed '('`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			problems := sources.ValidateDefinition(test.name+".yml", []byte(test.content))
+
+			require.Len(t, problems, 1)
+			// the parser messages come from the selector libraries
+			assert.Contains(t, problems[0].String(), test.want)
+		})
+	}
+}
+
+func TestValidateDuplicatedNames(t *testing.T) {
+	t.Parallel()
+
+	first := t.TempDir()
+	second := t.TempDir()
+
+	require.NoError(t, os.WriteFile(filepath.Join(first, "valid.yml"), []byte(validSource), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(second, "copy.yml"), []byte(validSource), 0o600))
+
+	problems, err := sources.Validate(context.TODO(), []string{first})
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+
+	problems, err = sources.Validate(context.TODO(), []string{first, second})
+	require.NoError(t, err)
+	require.Len(t, problems, 1)
+	assert.Equal(t, filepath.Join(second, "copy.yml"), problems[0].File)
+	assert.Equal(t, 1, problems[0].Line)
+	assert.Contains(t, problems[0].Message, `duplicate name "VALID", already defined in `+filepath.Join(first, "valid.yml")+":1")
+}
+
+func TestValidateDefinitionFetcher(t *testing.T) {
+	t.Parallel()
+
+	problems := sources.ValidateDefinition("chrome.yml", []byte(`name: CHROME
+base_url: https://example.com
+paths: [/news]
+fetcher: chrome
+wait_for: "#news article"
+attributes:
+  entry_selector: "#news article"
+  link: {path: a, attribute: href}
+  title: {path: h2}
+`))
+	assert.Empty(t, problems)
+
+	problems = sources.ValidateDefinition("firefox.yml", []byte(`name: FIREFOX
+base_url: https://example.com
+paths: [/api]
+parser: JSON
+fetcher: firefox
+attributes:
+  entry_selector: items
+  link: {path: url}
+  title: {path: title}
+`))
+
+	assert.Equal(t, []string{`firefox.yml:5:10: unknown fetcher "firefox", expected "http", "chrome" or empty`}, messages(problems))
+}
+
+func TestValidateDefinitionPagination(t *testing.T) {
+	t.Parallel()
+
+	problems := sources.ValidateDefinition("paged.yml", []byte(`name: PAGED
+base_url: https://example.com
+paths: [/api]
+parser: JSON
+pagination:
+  cursor: meta.next
+  template: /api?after={cursor}
+  max_pages: 3
+attributes:
+  entry_selector: items
+  link: {path: url}
+  title: {path: title}
+`))
+	assert.Empty(t, problems)
+
+	problems = sources.ValidateDefinition("broken.yml", []byte(`name: BROKEN
+base_url: https://example.com
+paths: [/news]
+pagination:
+  next: "a[rel=next"
+  cursor: meta.next
+  template: /news/page
+  max_pages: -1
+attributes:
+  entry_selector: article
+  link: {path: a, attribute: href}
+  title: {path: h2}
+`))
+
+	assert.Len(t, problems, 5)
+	assert.Equal(t, "broken.yml:8:14: max_pages must not be negative", problems[0].String())
+	assert.Contains(t, problems[1].String(), `broken.yml:5:9: invalid selector "a[rel=next"`)
+	assert.Equal(t, "broken.yml:6:11: cursor is only supported by the JSON parser", problems[2].String())
+	assert.Equal(t, "broken.yml:7:13: cursor requires a template with {cursor}", problems[3].String())
+	assert.Equal(t, "broken.yml:7:13: template requires {page} or {cursor}", problems[4].String())
+}
+
+func TestValidateDefinitionFilters(t *testing.T) {
+	t.Parallel()
+
+	problems := sources.ValidateDefinition("filters.yml", []byte(`name: FILTERS
+base_url: https://example.com
+paths: [/news]
+filters:
+  include:
+    - words: [nintendo]
+      fields: [body]
+  exclude:
+    - regex: "review (de|do"
+attributes:
+  entry_selector: article
+  link: {path: a, attribute: href}
+  title: {path: h2}
+`))
+
+	assert.Len(t, problems, 2)
+	assert.Equal(t, `filters.yml:6:5: invalid filter rule: unknown field "body", expected title, url, category`, problems[0].String())
+	assert.Contains(t, problems[1].String(), "filters.yml:9:5: invalid filter rule: error parsing regexp")
+}
+
+func TestValidateNoSources(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+
+	require.NoError(t, os.Mkdir(filepath.Join(root, "games"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "games", "valid.yml"), []byte(validSource), 0o600))
+
+	// the subdirectories are not read, nothing was checked
+	_, err := sources.Validate(context.TODO(), []string{root})
+	assert.ErrorIs(t, err, sources.ErrNoSources.Msgf(root))
+
+	problems, err := sources.Validate(context.TODO(), []string{filepath.Join(root, "games")})
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+}
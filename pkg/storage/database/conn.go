@@ -29,6 +29,8 @@ type Options struct {
 	storage.Options `fig:",squash"    yaml:",inline"`
 	Path            string `fig:"path"       yaml:"path"`
 	MustExist       bool   `fig:"must_exist" yaml:"must_exist"`
+	// StripParams returns the strip_params of the sources by name, it is only called to re-hash the entries.
+	StripParams func(ctx context.Context) (map[string][]string, error) `fig:"-" yaml:"-"`
 }
 
 func Open(ctx context.Context, conf Options) (*sql.DB, error) {
@@ -49,7 +51,15 @@ func Open(ctx context.Context, conf Options) (*sql.DB, error) {
 		return nil, ErrFailToOpenDatabase.Wrap(err).Msgf(conf.Path)
 	}
 
-	return conn, applyMigrations(ctx, conn)
+	if err = applyMigrations(ctx, conn); err != nil {
+		return conn, err
+	}
+
+	if err = rehashEntries(ctx, conn, conf.StripParams); err != nil {
+		return conn, ErrFailToRunMigration.Wrap(err)
+	}
+
+	return conn, nil
 }
 
 func applyMigrations(ctx context.Context, conn *sql.DB) error {
@@ -11,7 +11,13 @@ import (
 	"github.com/vinicius73/gear-feed/pkg/support/apperrors"
 )
 
-var ErrFailedToCreateEntry = apperrors.System(nil, "failed to create entry", "DB:FailedToCreateEntry")
+var (
+	ErrFailedToCreateEntry    = apperrors.System(nil, "failed to create entry", "DB:FailedToCreateEntry")
+	ErrFailedToCreateDelivery = apperrors.System(nil, "failed to create delivery", "DB:FailedToCreateDelivery")
+	ErrFailedToStoreTaskRun   = apperrors.System(nil, "failed to store task run", "DB:FailedToStoreTaskRun")
+	ErrFailedToStoreHealth    = apperrors.System(nil, "failed to store source health", "DB:FailedToStoreHealth")
+	ErrFailedToEnqueue        = apperrors.System(nil, "failed to enqueue entry", "DB:FailedToEnqueue")
+)
 
 type Storage[T model.IEntry] struct {
 	ttl time.Duration
@@ -29,6 +35,10 @@ func NewStorage[T model.IEntry](db *sql.DB, opt Options) (storage.Storage[T], er
 
 	dbmap.AddTableWithName(DBEntry[T]{}, "entries")
 	dbmap.AddTableWithName(DBEntryToUpdate[T]{}, "entries")
+	dbmap.AddTableWithName(DBDelivery{}, "deliveries")
+	dbmap.AddTableWithName(DBTaskRun{}, "task_runs").SetKeys(true, "ID")
+	dbmap.AddTableWithName(DBSourceHealth{}, "source_health")
+	dbmap.AddTableWithName(DBQueueItem{}, "queue")
 
 	return Storage[T]{
 		ttl: opt.TTL,
@@ -42,13 +52,25 @@ func (s Storage[T]) Has(hash string) (bool, error) {
 	return res.Valid, err
 }
 
+// Store inserts the entry, an entry already stored only has its status updated,
+// which happens when it is sent to another destination.
 func (s Storage[T]) Store(entry storage.Entry[T]) error {
 	record, err := NewEntry[T](s.ttl, entry)
 	if err != nil {
 		return err
 	}
 
-	err = s.db.Insert(&record)
+	exists, err := s.Has(record.Hash)
+	if err != nil {
+		return ErrFailedToCreateEntry.Wrap(err)
+	}
+
+	if exists {
+		_, err = s.db.Exec("UPDATE entries SET status = ?, cluster = COALESCE(?, cluster) WHERE hash = ?", record.Status, record.Cluster, record.Hash)
+	} else {
+		err = s.db.Insert(&record)
+	}
+
 	if err != nil {
 		return ErrFailedToCreateEntry.Wrap(err)
 	}
@@ -56,6 +78,206 @@ func (s Storage[T]) Store(entry storage.Entry[T]) error {
 	return nil
 }
 
+func (s Storage[T]) StoreDelivery(delivery storage.Delivery) error {
+	record := NewDelivery(delivery)
+
+	//nolint:lll
+	_, err := s.db.Exec("INSERT OR REPLACE INTO deliveries (entry_hash, destination, kind, message_id, sent_at) VALUES (?, ?, ?, ?, ?)",
+		record.EntryHash, record.Destination, record.Kind, record.MessageID, record.SentAt)
+	if err != nil {
+		return ErrFailedToCreateDelivery.Wrap(err)
+	}
+
+	return nil
+}
+
+func (s Storage[T]) Deliveries(hash string) ([]storage.Delivery, error) {
+	var found []DBDelivery
+
+	_, err := s.db.Select(&found, "SELECT * FROM deliveries WHERE entry_hash = ? ORDER BY sent_at", hash)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]storage.Delivery, len(found))
+
+	for index, delivery := range found {
+		result[index] = delivery.ToDelivery()
+	}
+
+	return result, nil
+}
+
+// StoreTaskRun inserts the run when it has no ID, otherwise it is updated.
+func (s Storage[T]) StoreTaskRun(run storage.TaskRun) (storage.TaskRun, error) {
+	record, err := NewTaskRun(run)
+	if err != nil {
+		return run, ErrFailedToStoreTaskRun.Wrap(err)
+	}
+
+	if record.ID == 0 {
+		err = s.db.Insert(&record)
+	} else {
+		_, err = s.db.Update(&record)
+	}
+
+	if err != nil {
+		return run, ErrFailedToStoreTaskRun.Wrap(err)
+	}
+
+	run.ID = record.ID
+
+	return run, nil
+}
+
+// TaskRuns returns the task runs, newest first.
+func (s Storage[T]) TaskRuns(opt storage.FindTaskRunsOptions) ([]storage.TaskRun, error) {
+	var found []DBTaskRun
+
+	limit := opt.Limit
+
+	// sqlite has no limit when it is negative
+	if limit <= 0 {
+		limit = -1
+	}
+
+	query := "SELECT * FROM task_runs WHERE started_at >= :since"
+
+	if opt.Task != "" {
+		query += " AND task = :task"
+	}
+
+	_, err := s.db.Select(&found, query+" ORDER BY started_at DESC, id DESC LIMIT :limit", map[string]interface{}{
+		"since": opt.Since,
+		"task":  opt.Task,
+		"limit": limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]storage.TaskRun, len(found))
+
+	for index, run := range found {
+		result[index] = run.ToTaskRun()
+	}
+
+	return result, nil
+}
+
+func (s Storage[T]) StoreSourceHealth(health storage.SourceHealth) error {
+	record := NewSourceHealth(health)
+
+	//nolint:lll
+	_, err := s.db.Exec("INSERT OR REPLACE INTO source_health (source, last_success, last_failure, last_error, consecutive_failures, zero_entry_runs, cloudflare_hits, quarantined_until, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		record.Source, record.LastSuccess, record.LastFailure, record.LastError, record.ConsecutiveFailures,
+		record.ZeroEntryRuns, record.CloudflareHits, record.QuarantinedUntil, record.UpdatedAt)
+	if err != nil {
+		return ErrFailedToStoreHealth.Wrap(err)
+	}
+
+	return nil
+}
+
+// SourcesHealth returns the health of the sources, all of them when no name is given.
+func (s Storage[T]) SourcesHealth(names ...string) ([]storage.SourceHealth, error) {
+	var found []DBSourceHealth
+
+	query := "SELECT * FROM source_health"
+
+	if len(names) > 0 {
+		query += " WHERE source IN (:sources)"
+	}
+
+	_, err := s.db.Select(&found, query+" ORDER BY source", map[string]interface{}{
+		"sources": names,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]storage.SourceHealth, len(found))
+
+	for index, health := range found {
+		result[index] = health.ToSourceHealth()
+	}
+
+	return result, nil
+}
+
+// Enqueue inserts the items, the entries already queued keep their planned time.
+func (s Storage[T]) Enqueue(items ...storage.QueueItem[T]) (int, error) {
+	added := 0
+
+	for _, item := range items {
+		record, err := NewQueueItem(item)
+		if err != nil {
+			return added, ErrFailedToEnqueue.Wrap(err)
+		}
+
+		//nolint:lll
+		res, err := s.db.Exec("INSERT OR IGNORE INTO queue (entry_hash, source_name, data, planned_at, created_at) VALUES (?, ?, ?, ?, ?)",
+			record.EntryHash, record.SourceName, record.Data, record.PlannedAt, record.CreatedAt)
+		if err != nil {
+			return added, ErrFailedToEnqueue.Wrap(err)
+		}
+
+		if count, _ := res.RowsAffected(); count > 0 {
+			added++
+		}
+	}
+
+	return added, nil
+}
+
+// Queue returns the queued items, the first planned first.
+func (s Storage[T]) Queue(opt storage.FindQueueOptions) ([]storage.QueueItem[T], error) {
+	var found []DBQueueItem
+
+	limit := opt.Limit
+
+	// sqlite has no limit when it is negative
+	if limit <= 0 {
+		limit = -1
+	}
+
+	query := "SELECT * FROM queue"
+
+	if !opt.Until.IsZero() {
+		query += " WHERE planned_at <= :until"
+	}
+
+	_, err := s.db.Select(&found, query+" ORDER BY planned_at, created_at LIMIT :limit", map[string]interface{}{
+		"until": opt.Until.UTC(),
+		"limit": limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]storage.QueueItem[T], len(found))
+
+	for index, item := range found {
+		if result[index], err = ToQueueItem[T](item); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+func (s Storage[T]) Dequeue(hashes ...string) error {
+	if len(hashes) == 0 {
+		return nil
+	}
+
+	_, err := s.db.Exec("DELETE FROM queue WHERE entry_hash IN (:hashes)", map[string]interface{}{
+		"hashes": hashes,
+	})
+
+	return err
+}
+
 func (s Storage[T]) Update(entry storage.Entry[T]) error {
 	record, err := EntryToUpdate[T](entry)
 	if err != nil {
@@ -109,6 +331,89 @@ func (s Storage[T]) FindByHasStory(opt storage.FindByHasStoryOptions) ([]T, erro
 	return result, nil
 }
 
+// FindLatest returns the last sent entries, newest first.
+func (s Storage[T]) FindLatest(limit int) ([]T, error) {
+	found, err := s.findSent("", limit, map[string]interface{}{})
+	if err != nil {
+		return nil, err
+	}
+
+	return toEntries(found), nil
+}
+
+// FindRecent returns the sent entries created since the time, newest first.
+func (s Storage[T]) FindRecent(since time.Time) ([]T, error) {
+	found, err := s.findSent(" AND created_at >= :since", 0, map[string]interface{}{
+		"since": since,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toEntries(found), nil
+}
+
+// FindSent returns the sent entries with their metadata, newest first.
+func (s Storage[T]) FindSent(opt storage.FindSentOptions) ([]storage.Entry[T], error) {
+	conditions := ""
+
+	if len(opt.SourceNames) > 0 {
+		conditions = " AND source_name IN (:sources)"
+	}
+
+	found, err := s.findSent(conditions, opt.Limit, map[string]interface{}{
+		"sources": opt.SourceNames,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]storage.Entry[T], len(found))
+
+	for index, entry := range found {
+		var e T
+
+		result[index] = storage.Entry[T]{
+			Data:      entry.ToEntry(e),
+			Status:    entry.Status,
+			CreatedAt: entry.CreatedAt,
+		}
+	}
+
+	return result, nil
+}
+
+// findSent selects the sent entries matching the conditions, newest first. Zero limit returns all of them.
+func (s Storage[T]) findSent(conditions string, limit int, params map[string]interface{}) ([]DBEntry[T], error) {
+	var found []DBEntry[T]
+
+	query := "SELECT * FROM entries WHERE status = :status" + conditions + " ORDER BY created_at DESC"
+	params["status"] = storage.StatusSent
+
+	if limit > 0 {
+		query += " LIMIT :limit"
+		params["limit"] = limit
+	}
+
+	if _, err := s.db.Select(&found, query, params); err != nil {
+		return nil, err
+	}
+
+	return found, nil
+}
+
+func toEntries[T model.IEntry](found []DBEntry[T]) []T {
+	result := make([]T, len(found))
+
+	for index, entry := range found {
+		var e T
+
+		result[index] = entry.ToEntry(e)
+	}
+
+	return result
+}
+
 func (s Storage[T]) Where(where storage.WhereOptions, list []T) ([]T, error) {
 	hashMap, hashs, err := GroupByHash(list)
 	if err != nil {
@@ -125,6 +430,12 @@ func (s Storage[T]) Where(where storage.WhereOptions, list []T) ([]T, error) {
 		return nil, err
 	}
 
+	if len(where.Destinations) > 0 {
+		if found, err = s.statusByDeliveries(found, hashs, where.Destinations); err != nil {
+			return nil, err
+		}
+	}
+
 	foundMap := map[string]DBEntry[T]{}
 
 	for _, entry := range found {
@@ -144,12 +455,47 @@ func (s Storage[T]) Where(where storage.WhereOptions, list []T) ([]T, error) {
 	return result, nil
 }
 
+// statusByDeliveries defines the entries status from the messages delivered to the destinations.
+func (s Storage[T]) statusByDeliveries(entries []DBEntry[T], hashs, destinations []string) ([]DBEntry[T], error) {
+	found := []DBDelivery{}
+
+	//nolint:lll
+	_, err := s.db.Select(&found, "SELECT entry_hash, destination FROM deliveries WHERE kind = :kind AND entry_hash IN (:hashs) AND destination IN (:destinations)", map[string]interface{}{
+		"kind":         storage.DeliveryMessage,
+		"hashs":        hashs,
+		"destinations": append([]string{storage.AnyDestination}, destinations...),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	grouped := map[string][]DBDelivery{}
+
+	for _, delivery := range found {
+		grouped[delivery.EntryHash] = append(grouped[delivery.EntryHash], delivery)
+	}
+
+	for index, entry := range entries {
+		entries[index].Status = storage.StatusNew
+
+		if Delivered(grouped[entry.Hash], destinations) {
+			entries[index].Status = storage.StatusSent
+		}
+	}
+
+	return entries, nil
+}
+
 func (s Storage[T]) Cleanup() (int64, error) {
 	res, err := s.db.Exec("DELETE FROM entries WHERE ttl < ?", time.Now())
 	if err != nil {
 		return 0, err
 	}
 
+	if _, err = s.db.Exec("DELETE FROM deliveries WHERE entry_hash NOT IN (SELECT hash FROM entries)"); err != nil {
+		return 0, err
+	}
+
 	return res.RowsAffected()
 }
 
@@ -0,0 +1,66 @@
+package database
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/vinicius73/gear-feed/pkg/storage"
+)
+
+type DBDelivery struct {
+	EntryHash   string         `db:"entry_hash"`
+	Destination string         `db:"destination"`
+	Kind        string         `db:"kind"`
+	MessageID   sql.NullString `db:"message_id"`
+	SentAt      time.Time      `db:"sent_at"`
+}
+
+func NewDelivery(delivery storage.Delivery) DBDelivery {
+	sentAt := delivery.SentAt
+
+	if sentAt.IsZero() {
+		sentAt = time.Now()
+	}
+
+	return DBDelivery{
+		EntryHash:   delivery.Hash,
+		Destination: delivery.Destination,
+		Kind:        string(delivery.Kind),
+		MessageID: sql.NullString{
+			String: delivery.MessageID,
+			Valid:  delivery.MessageID != "",
+		},
+		SentAt: sentAt,
+	}
+}
+
+func (d DBDelivery) ToDelivery() storage.Delivery {
+	return storage.Delivery{
+		Hash:        d.EntryHash,
+		Destination: d.Destination,
+		Kind:        storage.DeliveryKind(d.Kind),
+		MessageID:   d.MessageID.String,
+		SentAt:      d.SentAt,
+	}
+}
+
+// Delivered reports if the deliveries cover all destinations.
+func Delivered(deliveries []DBDelivery, destinations []string) bool {
+	found := map[string]bool{}
+
+	for _, delivery := range deliveries {
+		if delivery.Destination == storage.AnyDestination {
+			return true
+		}
+
+		found[delivery.Destination] = true
+	}
+
+	for _, destination := range destinations {
+		if !found[destination] {
+			return false
+		}
+	}
+
+	return len(destinations) > 0
+}
@@ -0,0 +1,93 @@
+package database_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vinicius73/gear-feed/pkg/model"
+	"github.com/vinicius73/gear-feed/pkg/storage"
+	"github.com/vinicius73/gear-feed/pkg/storage/database"
+)
+
+func newStorage(t *testing.T) storage.Storage[model.Entry] {
+	t.Helper()
+
+	opts := database.Options{
+		Options: storage.Options{TTL: time.Hour},
+		Path:    filepath.Join(t.TempDir(), "test.sqlite"),
+	}
+
+	db, err := database.Open(context.TODO(), opts)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { db.Close() })
+
+	store, err := database.NewStorage[model.Entry](db, opts)
+	require.NoError(t, err)
+
+	return store
+}
+
+func TestWhereNotSentToDestinations(t *testing.T) {
+	t.Parallel()
+
+	store := newStorage(t)
+
+	sent := model.Entry{Title: "Entry 1", URL: "https://foo.bar/1", SourceName: "FOO"}
+	fresh := model.Entry{Title: "Entry 2", URL: "https://foo.bar/2", SourceName: "FOO"}
+
+	hash, err := sent.Hash()
+	require.NoError(t, err)
+
+	require.NoError(t, store.Store(storage.Entry[model.Entry]{Data: sent, Status: storage.StatusSent}))
+	require.NoError(t, store.StoreDelivery(storage.Delivery{
+		Hash:        hash,
+		Destination: "telegram:10",
+		Kind:        storage.DeliveryMessage,
+		MessageID:   "99",
+	}))
+
+	// storing again, when sent to another destination, must not fail
+	require.NoError(t, store.Store(storage.Entry[model.Entry]{Data: sent, Status: storage.StatusSent}))
+
+	list := []model.Entry{sent, fresh}
+
+	found, err := store.Where(storage.WhereNotSent(), list)
+	require.NoError(t, err)
+	assert.Equal(t, []model.Entry{fresh}, found)
+
+	found, err = store.Where(storage.WhereNotSent("telegram:10"), list)
+	require.NoError(t, err)
+	assert.Equal(t, []model.Entry{fresh}, found)
+
+	found, err = store.Where(storage.WhereNotSent("telegram:20"), list)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, list, found)
+
+	found, err = store.Where(storage.WhereNotSent("telegram:10", "discord"), list)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, list, found)
+
+	deliveries, err := store.Deliveries(hash)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, "telegram:10", deliveries[0].Destination)
+	assert.Equal(t, "99", deliveries[0].MessageID)
+	assert.Equal(t, storage.DeliveryMessage, deliveries[0].Kind)
+}
+
+func TestDelivered(t *testing.T) {
+	t.Parallel()
+
+	deliveries := []database.DBDelivery{{Destination: "a"}, {Destination: "b"}}
+
+	assert.True(t, database.Delivered(deliveries, []string{"a", "b"}))
+	assert.False(t, database.Delivered(deliveries, []string{"a", "c"}))
+	assert.False(t, database.Delivered(nil, []string{"a"}))
+	assert.False(t, database.Delivered(deliveries, nil))
+	assert.True(t, database.Delivered([]database.DBDelivery{{Destination: storage.AnyDestination}}, []string{"c"}))
+}
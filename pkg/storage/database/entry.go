@@ -1,6 +1,7 @@
 package database
 
 import (
+	"database/sql"
 	"encoding/json"
 	"time"
 
@@ -9,16 +10,20 @@ import (
 )
 
 type DBEntry[T model.IEntry] struct {
-	Hash       string         `db:"hash,primarykey"`
-	SourceName string         `db:"source_name"`
-	ImageURL   string         `db:"image_url"`
-	Text       string         `db:"text"`
-	Categories []byte         `db:"categories"`
-	URL        string         `db:"url"`
-	Status     storage.Status `db:"status"`
-	CreatedAt  time.Time      `db:"created_at"`
-	HasStory   bool           `db:"has_story"`
-	TTL        time.Time      `db:"ttl"`
+	Hash        string         `db:"hash,primarykey"`
+	SourceName  string         `db:"source_name"`
+	ImageURL    string         `db:"image_url"`
+	Text        string         `db:"text"`
+	Categories  []byte         `db:"categories"`
+	URL         string         `db:"url"`
+	Status      storage.Status `db:"status"`
+	CreatedAt   time.Time      `db:"created_at"`
+	PublishedAt sql.NullTime   `db:"published_at"`
+	HasStory    bool           `db:"has_story"`
+	TTL         time.Time      `db:"ttl"`
+	Cluster     sql.NullString `db:"cluster"`
+	Description sql.NullString `db:"description"`
+	SiteName    sql.NullString `db:"site_name"`
 }
 
 type DBEntryToUpdate[T model.IEntry] struct {
@@ -30,12 +35,16 @@ type DBEntryToUpdate[T model.IEntry] struct {
 func (e DBEntry[T]) ToEntry(target T) T {
 	//nolint:forcetypeassert
 	return target.FillFrom(model.Entry{
-		Title:      e.Text,
-		URL:        e.URL,
-		Image:      e.ImageURL,
-		HaveStory:  e.HasStory,
-		SourceName: e.SourceName,
-		Categories: []string{},
+		Title:       e.Text,
+		URL:         e.URL,
+		Image:       e.ImageURL,
+		HaveStory:   e.HasStory,
+		SourceName:  e.SourceName,
+		Categories:  []string{},
+		PublishedAt: e.PublishedAt.Time,
+		Description: e.Description.String,
+		SiteName:    e.SiteName.String,
+		ClusterID:   e.Cluster.String,
 	}).(T)
 }
 
@@ -63,6 +72,22 @@ func NewEntry[T model.IEntry](ttl time.Duration, entry storage.Entry[T]) (DBEntr
 		Categories: categories,
 		CreatedAt:  time.Now(),
 		TTL:        time.Now().Add(ttl),
+		PublishedAt: sql.NullTime{
+			Time:  source.Published(),
+			Valid: !source.Published().IsZero(),
+		},
+		Cluster: sql.NullString{
+			String: source.Cluster(),
+			Valid:  source.Cluster() != "",
+		},
+		Description: sql.NullString{
+			String: source.Summary(),
+			Valid:  source.Summary() != "",
+		},
+		SiteName: sql.NullString{
+			String: source.Site(),
+			Valid:  source.Site() != "",
+		},
 	}, nil
 }
 
@@ -0,0 +1,11 @@
+-- +migrate Up
+ALTER TABLE entries
+ADD COLUMN "published_at" datetime;
+
+CREATE INDEX entries_published_at_IDX ON entries (published_at);
+
+-- +migrate Down
+DROP INDEX entries_published_at_IDX;
+
+ALTER TABLE entries
+DROP COLUMN "published_at";
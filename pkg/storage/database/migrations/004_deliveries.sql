@@ -0,0 +1,18 @@
+-- +migrate Up
+CREATE TABLE deliveries (
+	entry_hash varchar(64) not null,
+	destination varchar(255) not null,
+	kind varchar(16) not null,
+	message_id varchar(255),
+	sent_at datetime not null,
+	CONSTRAINT deliveries_PK PRIMARY KEY (entry_hash, destination, kind)
+);
+
+CREATE INDEX deliveries_destination_IDX ON deliveries (destination);
+
+-- entries sent before the deliveries tracking, are considered sent to any destination
+INSERT INTO deliveries (entry_hash, destination, kind, sent_at)
+SELECT hash, '*', 'message', created_at FROM entries WHERE status = 2;
+
+-- +migrate Down
+DROP TABLE deliveries;
@@ -0,0 +1,17 @@
+-- +migrate Up
+CREATE TABLE task_runs (
+	id integer PRIMARY KEY AUTOINCREMENT,
+	task varchar(64) not null,
+	schedule varchar(255) not null default '',
+	status varchar(16) not null,
+	started_at datetime not null,
+	finished_at datetime,
+	error_code varchar(64),
+	error text,
+	counters text
+);
+
+CREATE INDEX task_runs_task_IDX ON task_runs (task, started_at);
+
+-- +migrate Down
+DROP TABLE task_runs;
@@ -0,0 +1,15 @@
+-- +migrate Up
+CREATE TABLE source_health (
+	source varchar(255) PRIMARY KEY,
+	last_success datetime,
+	last_failure datetime,
+	last_error text,
+	consecutive_failures integer not null default 0,
+	zero_entry_runs integer not null default 0,
+	cloudflare_hits integer not null default 0,
+	quarantined_until datetime,
+	updated_at datetime not null
+);
+
+-- +migrate Down
+DROP TABLE source_health;
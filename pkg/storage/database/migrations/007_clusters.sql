@@ -0,0 +1,11 @@
+-- +migrate Up
+ALTER TABLE entries
+ADD COLUMN "cluster" varchar(64);
+
+CREATE INDEX entries_cluster_IDX ON entries (cluster);
+
+-- +migrate Down
+DROP INDEX entries_cluster_IDX;
+
+ALTER TABLE entries
+DROP COLUMN "cluster";
@@ -0,0 +1,15 @@
+-- +migrate Up
+CREATE TABLE queue (
+	entry_hash varchar(255) PRIMARY KEY,
+	source_name varchar(255) not null,
+	data text not null,
+	planned_at datetime not null,
+	created_at datetime not null
+);
+
+CREATE INDEX queue_planned_at_IDX ON queue (planned_at);
+
+-- +migrate Down
+DROP INDEX queue_planned_at_IDX;
+
+DROP TABLE queue;
@@ -0,0 +1,13 @@
+-- +migrate Up
+ALTER TABLE entries
+ADD COLUMN "description" text;
+
+ALTER TABLE entries
+ADD COLUMN "site_name" varchar(255);
+
+-- +migrate Down
+ALTER TABLE entries
+DROP COLUMN "site_name";
+
+ALTER TABLE entries
+DROP COLUMN "description";
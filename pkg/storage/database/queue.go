@@ -0,0 +1,62 @@
+package database
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/vinicius73/gear-feed/pkg/model"
+	"github.com/vinicius73/gear-feed/pkg/storage"
+)
+
+// DBQueueItem keeps the whole entry, the data filled by the enrichment is not stored in the entries.
+type DBQueueItem struct {
+	EntryHash  string    `db:"entry_hash,primarykey"`
+	SourceName string    `db:"source_name"`
+	Data       []byte    `db:"data"`
+	PlannedAt  time.Time `db:"planned_at"`
+	CreatedAt  time.Time `db:"created_at"`
+}
+
+func NewQueueItem[T model.IEntry](item storage.QueueItem[T]) (DBQueueItem, error) {
+	hash, err := item.Data.Hash()
+	if err != nil {
+		return DBQueueItem{}, err
+	}
+
+	data, err := json.Marshal(item.Data)
+	if err != nil {
+		return DBQueueItem{}, err
+	}
+
+	createdAt := item.CreatedAt
+
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+
+	// sqlite compares the times as text, they must have the same offset
+	return DBQueueItem{
+		EntryHash:  hash,
+		SourceName: item.Data.Source(),
+		Data:       data,
+		PlannedAt:  item.PlannedAt.UTC(),
+		CreatedAt:  createdAt.UTC(),
+	}, nil
+}
+
+func ToQueueItem[T model.IEntry](item DBQueueItem) (storage.QueueItem[T], error) {
+	var (
+		entry  model.Entry
+		target T
+	)
+
+	if err := json.Unmarshal(item.Data, &entry); err != nil {
+		return storage.QueueItem[T]{}, err
+	}
+
+	return storage.QueueItem[T]{
+		Data:      target.FillFrom(entry).(T), //nolint:forcetypeassert
+		PlannedAt: item.PlannedAt,
+		CreatedAt: item.CreatedAt,
+	}, nil
+}
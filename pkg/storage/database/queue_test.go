@@ -0,0 +1,86 @@
+package database_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vinicius73/gear-feed/pkg/model"
+	"github.com/vinicius73/gear-feed/pkg/storage"
+)
+
+func TestQueue(t *testing.T) {
+	t.Parallel()
+
+	store := newStorage(t)
+	now := time.Now().Truncate(time.Second)
+
+	first := model.Entry{Title: "first", URL: "https://foo.com/1", SourceName: "FOO", Description: "enriched"}
+	second := model.Entry{Title: "second", URL: "https://foo.com/2", SourceName: "FOO"}
+
+	added, err := store.Enqueue(
+		storage.QueueItem[model.Entry]{Data: second, PlannedAt: now.Add(time.Hour)},
+		storage.QueueItem[model.Entry]{Data: first, PlannedAt: now},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, 2, added)
+
+	// the planned time of a queued entry is kept
+	added, err = store.Enqueue(storage.QueueItem[model.Entry]{Data: first, PlannedAt: now.Add(time.Hour * 2)})
+	require.NoError(t, err)
+	assert.Equal(t, 0, added)
+
+	items, err := store.Queue(storage.FindQueueOptions{})
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.Equal(t, first, items[0].Data)
+	assert.True(t, now.Equal(items[0].PlannedAt))
+	assert.Equal(t, "second", items[1].Data.Title)
+
+	items, err = store.Queue(storage.FindQueueOptions{Until: now.Add(time.Minute)})
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, "first", items[0].Data.Title)
+
+	hash, err := first.Hash()
+	require.NoError(t, err)
+	require.NoError(t, store.Dequeue(hash))
+
+	items, err = store.Queue(storage.FindQueueOptions{})
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, "second", items[0].Data.Title)
+}
+
+func TestQueueTimezones(t *testing.T) {
+	t.Parallel()
+
+	store := newStorage(t)
+	saoPaulo := time.FixedZone("America/Sao_Paulo", -3*60*60)
+	now := time.Date(2024, 5, 10, 10, 0, 0, 0, time.UTC)
+
+	_, err := store.Enqueue(
+		storage.QueueItem[model.Entry]{
+			Data:      model.Entry{Title: "later", URL: "https://foo.com/1", SourceName: "FOO"},
+			PlannedAt: now.In(saoPaulo),
+		},
+		storage.QueueItem[model.Entry]{
+			Data:      model.Entry{Title: "sooner", URL: "https://foo.com/2", SourceName: "FOO"},
+			PlannedAt: now.Add(-time.Minute * 30),
+		},
+	)
+	require.NoError(t, err)
+
+	// the times are compared by instant, not by the text of their offsets
+	items, err := store.Queue(storage.FindQueueOptions{})
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.Equal(t, "sooner", items[0].Data.Title)
+	assert.True(t, now.Equal(items[1].PlannedAt))
+
+	items, err = store.Queue(storage.FindQueueOptions{Until: now.Add(-time.Minute).In(saoPaulo)})
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, "sooner", items[0].Data.Title)
+}
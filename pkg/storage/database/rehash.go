@@ -0,0 +1,150 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+
+	"github.com/rs/zerolog"
+	"github.com/vinicius73/gear-feed/pkg/model"
+	"github.com/vinicius73/gear-feed/pkg/support"
+)
+
+// hashVersion is kept in the sqlite user_version, the entries are re-hashed when the hash of model.Entry changes.
+const hashVersion = 1
+
+type hashedEntry struct {
+	hash   string
+	url    string
+	source string
+	status int
+}
+
+// rehashEntries updates the hashes of the stored entries, the entries with the same new hash are merged.
+// It is not a sql-migrate migration because the hashes are computed in Go, with the strip_params of the
+// sources, which are not available to the SQL files. The PRAGMA user_version records the hash version,
+// so it runs once for each change of the hash.
+func rehashEntries(ctx context.Context, conn *sql.DB, stripParams func(ctx context.Context) (map[string][]string, error)) error {
+	var version int
+
+	if err := conn.QueryRowContext(ctx, "PRAGMA user_version").Scan(&version); err != nil {
+		return err
+	}
+
+	if version >= hashVersion {
+		return nil
+	}
+
+	params := map[string][]string{}
+
+	if stripParams != nil {
+		loaded, err := stripParams(ctx)
+		if err != nil {
+			return err
+		}
+
+		params = loaded
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback() //nolint:errcheck
+
+	entries, err := hashedEntries(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	updated := 0
+
+	for _, entry := range entries {
+		// new entries have the strip_params of their source applied when they are scraped
+		hash, err := support.HashSHA256(model.HashKey(model.CanonicalURL(entry.url, params[entry.source]...)))
+		if err != nil {
+			return err
+		}
+
+		if hash == entry.hash {
+			continue
+		}
+
+		if err = moveEntry(ctx, tx, entry, hash); err != nil {
+			return err
+		}
+
+		updated++
+	}
+
+	// pragmas do not accept parameters
+	if _, err = tx.ExecContext(ctx, "PRAGMA user_version = "+strconv.Itoa(hashVersion)); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return err
+	}
+
+	zerolog.Ctx(ctx).Info().Int("entries", updated).Msg("Entries re-hashed")
+
+	return nil
+}
+
+func hashedEntries(ctx context.Context, tx *sql.Tx) ([]hashedEntry, error) {
+	rows, err := tx.QueryContext(ctx, "SELECT hash, url, source_name, status FROM entries")
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	entries := []hashedEntry{}
+
+	for rows.Next() {
+		var entry hashedEntry
+
+		if err = rows.Scan(&entry.hash, &entry.url, &entry.source, &entry.status); err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
+// moveEntry renames the entry, when the new hash exists the highest status is kept.
+func moveEntry(ctx context.Context, tx *sql.Tx, entry hashedEntry, hash string) error {
+	var status int
+
+	err := tx.QueryRowContext(ctx, "SELECT status FROM entries WHERE hash = ?", hash).Scan(&status)
+
+	switch {
+	case err == nil:
+		if entry.status > status {
+			if _, err = tx.ExecContext(ctx, "UPDATE entries SET status = ? WHERE hash = ?", entry.status, hash); err != nil {
+				return err
+			}
+		}
+
+		_, err = tx.ExecContext(ctx, "DELETE FROM entries WHERE hash = ?", entry.hash)
+	case errors.Is(err, sql.ErrNoRows):
+		_, err = tx.ExecContext(ctx, "UPDATE entries SET hash = ? WHERE hash = ?", hash, entry.hash)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	// the deliveries already recorded for the new hash are kept
+	if _, err = tx.ExecContext(ctx, "UPDATE OR IGNORE deliveries SET entry_hash = ? WHERE entry_hash = ?", hash, entry.hash); err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, "DELETE FROM deliveries WHERE entry_hash = ?", entry.hash)
+
+	return err
+}
@@ -0,0 +1,85 @@
+package database_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vinicius73/gear-feed/pkg/model"
+	"github.com/vinicius73/gear-feed/pkg/storage"
+	"github.com/vinicius73/gear-feed/pkg/storage/database"
+	"github.com/vinicius73/gear-feed/pkg/support"
+)
+
+func TestRehashEntries(t *testing.T) {
+	t.Parallel()
+
+	opts := database.Options{
+		Options: storage.Options{TTL: time.Hour},
+		Path:    filepath.Join(t.TempDir(), "test.sqlite"),
+		StripParams: func(context.Context) (map[string][]string, error) {
+			return map[string][]string{"FOO": {"ref"}}, nil
+		},
+	}
+
+	db, err := database.Open(context.TODO(), opts)
+	require.NoError(t, err)
+
+	// rows stored with the hash of the raw URL
+	insert := func(url string, status storage.Status) string {
+		hash, err := support.HashSHA256(url)
+		require.NoError(t, err)
+
+		_, err = db.Exec(
+			"INSERT INTO entries (hash, source_name, text, url, status, created_at, ttl) VALUES (?, 'FOO', 'text', ?, ?, ?, ?)",
+			hash, url, status, time.Now(), time.Now().Add(time.Hour),
+		)
+		require.NoError(t, err)
+
+		return hash
+	}
+
+	sentHash := insert("https://foo.com/news/1/?utm_source=feed", storage.StatusSent)
+	insert("http://foo.com/news/1", storage.StatusNew)
+	insert("https://foo.com/news/2", storage.StatusNew)
+	insert("https://foo.com/news/2?ref=home", storage.StatusNew)
+
+	_, err = db.Exec("INSERT INTO deliveries (entry_hash, destination, kind, sent_at) VALUES (?, 'telegram:1', 'message', ?)", sentHash, time.Now())
+	require.NoError(t, err)
+
+	_, err = db.Exec("PRAGMA user_version = 0")
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	db, err = database.Open(context.TODO(), opts)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { db.Close() })
+
+	store, err := database.NewStorage[model.Entry](db, opts)
+	require.NoError(t, err)
+
+	var count int
+
+	require.NoError(t, db.QueryRow("SELECT COUNT(*) FROM entries").Scan(&count))
+	assert.Equal(t, 2, count)
+
+	// the variants were merged, keeping the sent status and the deliveries
+	found, err := store.Where(storage.WhereNotSent("telegram:1"), []model.Entry{
+		{URL: "https://foo.com/news/1"},
+		{URL: "https://foo.com/news/2"},
+	})
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, "https://foo.com/news/2", found[0].URL)
+
+	hash, err := model.Entry{URL: "https://foo.com/news/1"}.Hash()
+	require.NoError(t, err)
+
+	deliveries, err := store.Deliveries(hash)
+	require.NoError(t, err)
+	assert.Len(t, deliveries, 1)
+}
@@ -0,0 +1,58 @@
+package database
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/vinicius73/gear-feed/pkg/storage"
+)
+
+type DBSourceHealth struct {
+	Source              string         `db:"source,primarykey"`
+	LastSuccess         sql.NullTime   `db:"last_success"`
+	LastFailure         sql.NullTime   `db:"last_failure"`
+	LastError           sql.NullString `db:"last_error"`
+	ConsecutiveFailures int            `db:"consecutive_failures"`
+	ZeroEntryRuns       int            `db:"zero_entry_runs"`
+	CloudflareHits      int            `db:"cloudflare_hits"`
+	QuarantinedUntil    sql.NullTime   `db:"quarantined_until"`
+	UpdatedAt           time.Time      `db:"updated_at"`
+}
+
+func NewSourceHealth(health storage.SourceHealth) DBSourceHealth {
+	updatedAt := health.UpdatedAt
+
+	if updatedAt.IsZero() {
+		updatedAt = time.Now()
+	}
+
+	return DBSourceHealth{
+		Source:              health.Source,
+		LastSuccess:         nullTime(health.LastSuccess),
+		LastFailure:         nullTime(health.LastFailure),
+		LastError:           sql.NullString{String: health.LastError, Valid: health.LastError != ""},
+		ConsecutiveFailures: health.ConsecutiveFailures,
+		ZeroEntryRuns:       health.ZeroEntryRuns,
+		CloudflareHits:      health.CloudflareHits,
+		QuarantinedUntil:    nullTime(health.QuarantinedUntil),
+		UpdatedAt:           updatedAt,
+	}
+}
+
+func (h DBSourceHealth) ToSourceHealth() storage.SourceHealth {
+	return storage.SourceHealth{
+		Source:              h.Source,
+		LastSuccess:         h.LastSuccess.Time,
+		LastFailure:         h.LastFailure.Time,
+		LastError:           h.LastError.String,
+		ConsecutiveFailures: h.ConsecutiveFailures,
+		ZeroEntryRuns:       h.ZeroEntryRuns,
+		CloudflareHits:      h.CloudflareHits,
+		QuarantinedUntil:    h.QuarantinedUntil.Time,
+		UpdatedAt:           h.UpdatedAt,
+	}
+}
+
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
@@ -0,0 +1,59 @@
+package database_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vinicius73/gear-feed/pkg/storage"
+)
+
+func TestSourcesHealth(t *testing.T) {
+	t.Parallel()
+
+	store := newStorage(t)
+	now := time.Now().Truncate(time.Second)
+
+	require.NoError(t, store.StoreSourceHealth(storage.SourceHealth{
+		Source:      "alpha",
+		LastSuccess: now,
+		UpdatedAt:   now,
+	}))
+
+	require.NoError(t, store.StoreSourceHealth(storage.SourceHealth{
+		Source:              "beta",
+		LastFailure:         now,
+		LastError:           "cloudflare challenge",
+		ConsecutiveFailures: 5,
+		CloudflareHits:      2,
+		QuarantinedUntil:    now.Add(time.Hour),
+		UpdatedAt:           now,
+	}))
+
+	list, err := store.SourcesHealth()
+	require.NoError(t, err)
+	assert.Len(t, list, 2)
+
+	list, err = store.SourcesHealth("beta", "gamma")
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+
+	beta := list[0]
+	assert.Equal(t, "beta", beta.Source)
+	assert.Equal(t, 5, beta.ConsecutiveFailures)
+	assert.Equal(t, 2, beta.CloudflareHits)
+	assert.True(t, beta.LastSuccess.IsZero())
+	assert.True(t, beta.Quarantined(now))
+
+	// replaces the previous state
+	beta.ConsecutiveFailures = 0
+	beta.QuarantinedUntil = time.Time{}
+	require.NoError(t, store.StoreSourceHealth(beta))
+
+	list, err = store.SourcesHealth("beta")
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Zero(t, list[0].ConsecutiveFailures)
+	assert.False(t, list[0].Quarantined(now))
+}
@@ -0,0 +1,65 @@
+package database
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"github.com/vinicius73/gear-feed/pkg/storage"
+)
+
+type DBTaskRun struct {
+	ID         int64          `db:"id"`
+	Task       string         `db:"task"`
+	Schedule   string         `db:"schedule"`
+	Status     string         `db:"status"`
+	StartedAt  sql.NullTime   `db:"started_at"`
+	FinishedAt sql.NullTime   `db:"finished_at"`
+	ErrorCode  sql.NullString `db:"error_code"`
+	Error      sql.NullString `db:"error"`
+	Counters   sql.NullString `db:"counters"`
+}
+
+func NewTaskRun(run storage.TaskRun) (DBTaskRun, error) {
+	record := DBTaskRun{
+		ID:         run.ID,
+		Task:       run.Task,
+		Schedule:   run.Schedule,
+		Status:     string(run.Status),
+		StartedAt:  sql.NullTime{Time: run.StartedAt, Valid: true},
+		FinishedAt: sql.NullTime{Time: run.FinishedAt, Valid: !run.FinishedAt.IsZero()},
+		ErrorCode:  sql.NullString{String: run.ErrorCode, Valid: run.ErrorCode != ""},
+		Error:      sql.NullString{String: run.Error, Valid: run.Error != ""},
+		Counters:   sql.NullString{String: "", Valid: false},
+	}
+
+	if len(run.Counters) > 0 {
+		counters, err := json.Marshal(run.Counters)
+		if err != nil {
+			return record, err
+		}
+
+		record.Counters = sql.NullString{String: string(counters), Valid: true}
+	}
+
+	return record, nil
+}
+
+func (r DBTaskRun) ToTaskRun() storage.TaskRun {
+	run := storage.TaskRun{
+		ID:         r.ID,
+		Task:       r.Task,
+		Schedule:   r.Schedule,
+		Status:     storage.TaskRunStatus(r.Status),
+		StartedAt:  r.StartedAt.Time,
+		FinishedAt: r.FinishedAt.Time,
+		ErrorCode:  r.ErrorCode.String,
+		Error:      r.Error.String,
+		Counters:   map[string]int{},
+	}
+
+	if r.Counters.Valid {
+		_ = json.Unmarshal([]byte(r.Counters.String), &run.Counters)
+	}
+
+	return run
+}
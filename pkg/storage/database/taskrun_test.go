@@ -0,0 +1,66 @@
+package database_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vinicius73/gear-feed/pkg/storage"
+)
+
+func TestTaskRuns(t *testing.T) {
+	t.Parallel()
+
+	store := newStorage(t)
+	now := time.Now()
+
+	old, err := store.StoreTaskRun(storage.TaskRun{
+		Task:      "cleanup",
+		Status:    storage.TaskRunSuccess,
+		StartedAt: now.Add(-time.Hour * 48),
+	})
+	require.NoError(t, err)
+	assert.NotZero(t, old.ID)
+
+	run, err := store.StoreTaskRun(storage.TaskRun{
+		Task:      "send_last_entries",
+		Schedule:  "0 8-23 * * *",
+		Status:    storage.TaskRunRunning,
+		StartedAt: now.Add(-time.Minute),
+	})
+	require.NoError(t, err)
+	assert.NotEqual(t, old.ID, run.ID)
+
+	run.Status = storage.TaskRunFailure
+	run.FinishedAt = now
+	run.ErrorCode = "SENDER:FAIL_TO_SEND"
+	run.Error = "SENDER:FAIL_TO_SEND: fail to send message"
+	run.Counters = map[string]int{"loaded": 10, "filtered": 3, "message_failed": 1}
+
+	updated, err := store.StoreTaskRun(run)
+	require.NoError(t, err)
+	assert.Equal(t, run.ID, updated.ID)
+
+	runs, err := store.TaskRuns(storage.FindTaskRunsOptions{Since: now.Add(-time.Hour * 72)})
+	require.NoError(t, err)
+	require.Len(t, runs, 2)
+
+	// newest first
+	assert.Equal(t, run.ID, runs[0].ID)
+	assert.Equal(t, storage.TaskRunFailure, runs[0].Status)
+	assert.Equal(t, "SENDER:FAIL_TO_SEND", runs[0].ErrorCode)
+	assert.Equal(t, "0 8-23 * * *", runs[0].Schedule)
+	assert.Equal(t, "filtered=3 loaded=10 message_failed=1", runs[0].FormatCounters())
+	assert.Equal(t, time.Minute, runs[0].Duration().Round(time.Second))
+
+	runs, err = store.TaskRuns(storage.FindTaskRunsOptions{Since: now.Add(-time.Hour * 24)})
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+
+	runs, err = store.TaskRuns(storage.FindTaskRunsOptions{Task: "cleanup", Since: now.Add(-time.Hour * 72), Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	assert.Equal(t, old.ID, runs[0].ID)
+	assert.Empty(t, runs[0].Counters)
+}
@@ -0,0 +1,22 @@
+package storage
+
+import "time"
+
+type DeliveryKind string
+
+const (
+	DeliveryMessage DeliveryKind = "message"
+	DeliveryStory   DeliveryKind = "story"
+)
+
+// AnyDestination marks deliveries made before destinations were tracked.
+const AnyDestination = "*"
+
+// Delivery records an entry sent to a destination.
+type Delivery struct {
+	Hash        string
+	Destination string
+	Kind        DeliveryKind
+	MessageID   string
+	SentAt      time.Time
+}
@@ -0,0 +1,21 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/vinicius73/gear-feed/pkg/model"
+)
+
+// QueueItem is an entry waiting to be sent by the queue dispatcher.
+type QueueItem[T model.IEntry] struct {
+	Data T
+	// PlannedAt is the time the entry should be sent.
+	PlannedAt time.Time
+	CreatedAt time.Time
+}
+
+type FindQueueOptions struct {
+	// Until returns only the items planned up to the time, all of them when zero.
+	Until time.Time
+	Limit int
+}
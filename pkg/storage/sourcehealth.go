@@ -0,0 +1,23 @@
+package storage
+
+import "time"
+
+// SourceHealth keeps the scrape outcomes of a source.
+type SourceHealth struct {
+	Source      string
+	LastSuccess time.Time
+	LastFailure time.Time
+	LastError   string
+	// ConsecutiveFailures is reset by a successful scrape.
+	ConsecutiveFailures int
+	// ZeroEntryRuns counts the consecutive successful scrapes without entries.
+	ZeroEntryRuns  int
+	CloudflareHits int
+	// QuarantinedUntil skips the source until the time is reached.
+	QuarantinedUntil time.Time
+	UpdatedAt        time.Time
+}
+
+func (h SourceHealth) Quarantined(now time.Time) bool {
+	return h.QuarantinedUntil.After(now)
+}
@@ -25,18 +25,38 @@ type FindByHasStoryOptions struct {
 	Has         bool
 }
 
+type FindSentOptions struct {
+	// SourceNames filters the entries, all sources are used when empty.
+	SourceNames []string
+	Limit       int
+}
+
 type Entry[T model.IEntry] struct {
 	Data   T
 	Status Status
+	// CreatedAt is only filled when the entry is read from the storage.
+	CreatedAt time.Time
 }
 
 type Storage[T model.IEntry] interface {
 	Has(hash string) (bool, error)
 	Store(entry Entry[T]) error
 	FindByHasStory(opt FindByHasStoryOptions) ([]T, error)
+	FindLatest(limit int) ([]T, error)
+	FindRecent(since time.Time) ([]T, error)
+	FindSent(opt FindSentOptions) ([]Entry[T], error)
 	Update(entry Entry[T]) error
 	Cleanup() (int64, error)
 	Where(opts WhereOptions, list []T) ([]T, error)
+	StoreDelivery(delivery Delivery) error
+	Deliveries(hash string) ([]Delivery, error)
+	StoreTaskRun(run TaskRun) (TaskRun, error)
+	TaskRuns(opt FindTaskRunsOptions) ([]TaskRun, error)
+	StoreSourceHealth(health SourceHealth) error
+	SourcesHealth(names ...string) ([]SourceHealth, error)
+	Enqueue(items ...QueueItem[T]) (int, error)
+	Queue(opt FindQueueOptions) ([]QueueItem[T], error)
+	Dequeue(hashes ...string) error
 }
 
 func (e Entry[T]) Hash() ([]byte, error) {
@@ -0,0 +1,63 @@
+package storage
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type TaskRunStatus string
+
+const (
+	TaskRunRunning TaskRunStatus = "running"
+	TaskRunSuccess TaskRunStatus = "success"
+	TaskRunFailure TaskRunStatus = "failure"
+)
+
+// TaskRun records an execution of a cron task.
+type TaskRun struct {
+	ID   int64
+	Task string
+	// Schedule is the cron expression that triggered the run, empty when it ran on demand.
+	Schedule   string
+	Status     TaskRunStatus
+	StartedAt  time.Time
+	FinishedAt time.Time
+	ErrorCode  string
+	Error      string
+	// Counters are reported by the task, like loaded, filtered and sent entries.
+	Counters map[string]int
+}
+
+type FindTaskRunsOptions struct {
+	// Task filters the runs, all tasks are used when empty.
+	Task  string
+	Since time.Time
+	Limit int
+}
+
+func (r TaskRun) Duration() time.Duration {
+	if r.FinishedAt.IsZero() {
+		return 0
+	}
+
+	return r.FinishedAt.Sub(r.StartedAt)
+}
+
+// FormatCounters returns the counters sorted by name, like "filtered=3 loaded=10".
+func (r TaskRun) FormatCounters() string {
+	names := make([]string, 0, len(r.Counters))
+
+	for name := range r.Counters {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for index, name := range names {
+		names[index] = name + "=" + strconv.Itoa(r.Counters[name])
+	}
+
+	return strings.Join(names, " ")
+}
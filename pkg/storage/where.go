@@ -4,14 +4,19 @@ type WhereOptions struct {
 	Is          *Status
 	Not         *Status
 	AllowMissed *bool
+	// Destinations evaluates the status by the deliveries,
+	// an entry is sent only when it was delivered to all destinations.
+	Destinations []string
 }
 
 type WhereOption func(opts *WhereOptions)
 
-func WhereNotSent() WhereOptions {
+// WhereNotSent matches entries not sent, or not sent to one of the destinations.
+func WhereNotSent(destinations ...string) WhereOptions {
 	return Where(
 		WhereAllowMissed(true),
 		WhereNot(StatusSent),
+		WhereDestinations(destinations...),
 	)
 }
 
@@ -42,3 +47,9 @@ func WhereAllowMissed(allow bool) WhereOption {
 		opts.AllowMissed = &allow
 	}
 }
+
+func WhereDestinations(destinations ...string) WhereOption {
+	return func(opts *WhereOptions) {
+		opts.Destinations = destinations
+	}
+}
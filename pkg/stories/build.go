@@ -2,8 +2,10 @@ package stories
 
 import (
 	"context"
+	"time"
 
 	"github.com/rs/zerolog"
+	"github.com/vinicius73/gear-feed/pkg/metrics"
 	"github.com/vinicius73/gear-feed/pkg/stories/fetcher"
 	"github.com/vinicius73/gear-feed/pkg/stories/filetemplate"
 	"github.com/vinicius73/gear-feed/pkg/stories/stages"
@@ -47,6 +49,16 @@ func (bo BuildStorieOptions) Template(source fetcher.Result) (filetemplate.Templ
 }
 
 func BuildStory(ctx context.Context, opt BuildStorieOptions) (Story, error) {
+	startedAt := time.Now()
+
+	story, err := buildStory(ctx, opt)
+
+	metrics.StoryBuildDuration.Observe(metrics.Since(startedAt), metrics.Result(err))
+
+	return story, err
+}
+
+func buildStory(ctx context.Context, opt BuildStorieOptions) (Story, error) {
 	logger := zerolog.Ctx(ctx).With().Str("component", "stories").Logger()
 	ctx = logger.WithContext(ctx)
 
@@ -54,6 +66,7 @@ func BuildStory(ctx context.Context, opt BuildStorieOptions) (Story, error) {
 		SourceURL:     opt.SourceURL,
 		DefaultWidth:  stages.DefaultWidth,
 		DefaultHeight: stages.DefaultHeight,
+		Transport:     nil,
 	})
 	if err != nil {
 		return Story{}, err
@@ -1,6 +1,7 @@
 package fetcher
 
 import (
+	"bytes"
 	"context"
 	"io"
 	"net/http"
@@ -12,9 +13,11 @@ import (
 	"github.com/otiai10/opengraph/v2"
 	"github.com/vinicius73/gear-feed/pkg/support"
 	"github.com/vinicius73/gear-feed/pkg/support/apperrors"
+	"golang.org/x/net/html"
 )
 
 var (
+	ErrNotHTML         = apperrors.Business("content type must be text/html", "FETCHER:NOT_HTML")
 	ErrMissingImageURL = apperrors.Business("missing image url", "FETCHER:MISSING_IMAGE_URL")
 	ErrFailToHash      = apperrors.System(nil, "fail to hash", "STAGES:FAIL_TO_HASH")
 )
@@ -27,6 +30,8 @@ type Options struct {
 	SourceURL     string
 	DefaultWidth  int
 	DefaultHeight int
+	// Transport of the page request, nil means the default http transport.
+	Transport http.RoundTripper
 }
 
 type Result struct {
@@ -37,22 +42,42 @@ type Result struct {
 	DomainName string
 	URL        string
 	Hash       string
+	// PublishedAt is zero when the page does not provide it.
+	PublishedAt time.Time
+	// Canonical is the absolute <link rel="canonical"> of the page, empty when it is not provided.
+	Canonical string
+}
+
+// publishedMeta are the names, properties or itemprops of the published date meta tags.
+var publishedMeta = []string{
+	"article:published_time",
+	"og:published_time",
+	"datepublished",
+	"pubdate",
+	"publish-date",
+}
+
+var publishedLayouts = []string{
+	time.RFC3339Nano,
+	time.RFC3339,
+	"2006-01-02T15:04:05Z0700",
+	"2006-01-02T15:04:05",
+	"2006-01-02",
 }
 
 func Fetch(ctx context.Context, opt Options) (Result, error) {
-	//nolint:exhaustruct
-	httpClient := &http.Client{
-		Timeout: requestTimeout,
+	body, err := fetchPage(ctx, opt)
+	if err != nil {
+		return Result{}, err
 	}
 
-	//nolint:exhaustruct
-	intent := opengraph.Intent{
-		Context:    ctx,
-		HTTPClient: httpClient,
+	ogp := opengraph.New(opt.SourceURL)
+
+	if err = ogp.Parse(bytes.NewReader(body)); err != nil {
+		return Result{}, err
 	}
 
-	ogp, err := opengraph.Fetch(opt.SourceURL, intent)
-	if err != nil {
+	if err = ogp.ToAbs(); err != nil {
 		return Result{}, err
 	}
 
@@ -81,6 +106,12 @@ func Fetch(ctx context.Context, opt Options) (Result, error) {
 		return Result{}, ErrFailToHash.Wrap(err)
 	}
 
+	published, canonical := scanHead(body)
+
+	if canonical != "" {
+		canonical = resolveURL(opt.SourceURL, canonical)
+	}
+
 	return Result{
 		Title:      strings.TrimSpace(title),
 		Text:       strings.TrimSpace(ogp.Description),
@@ -89,9 +120,122 @@ func Fetch(ctx context.Context, opt Options) (Result, error) {
 		SiteName:   siteName,
 		URL:        siteURL,
 		DomainName: parsed.Hostname(),
+
+		PublishedAt: published,
+		Canonical:   canonical,
 	}, nil
 }
 
+func fetchPage(ctx context.Context, opt Options) ([]byte, error) {
+	//nolint:exhaustruct
+	httpClient := &http.Client{
+		Timeout:   requestTimeout,
+		Transport: opt.Transport,
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, opt.SourceURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if !strings.HasPrefix(res.Header.Get("Content-Type"), "text/html") {
+		return nil, ErrNotHTML
+	}
+
+	return io.ReadAll(res.Body)
+}
+
+// scanHead looks for the published date meta tags and the canonical link of the page.
+func scanHead(body []byte) (time.Time, string) {
+	var (
+		published time.Time
+		canonical string
+	)
+
+	tokenizer := html.NewTokenizer(bytes.NewReader(body))
+
+	for published.IsZero() || canonical == "" {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			return published, canonical
+		case html.StartTagToken, html.SelfClosingTagToken:
+			token := tokenizer.Token()
+
+			if token.Data == "meta" && published.IsZero() {
+				published = parsePublishedMeta(token)
+			} else if token.Data == "link" && canonical == "" {
+				canonical = parseCanonicalLink(token)
+			}
+		}
+	}
+
+	return published, canonical
+}
+
+func parseCanonicalLink(token html.Token) string {
+	var rel, href string
+
+	for _, attr := range token.Attr {
+		switch attr.Key {
+		case "rel":
+			rel = strings.ToLower(strings.TrimSpace(attr.Val))
+		case "href":
+			href = strings.TrimSpace(attr.Val)
+		}
+	}
+
+	if rel != "canonical" {
+		return ""
+	}
+
+	return href
+}
+
+func resolveURL(base, link string) string {
+	parsedBase, err := url.Parse(base)
+	if err != nil {
+		return link
+	}
+
+	parsed, err := url.Parse(link)
+	if err != nil {
+		return ""
+	}
+
+	return parsedBase.ResolveReference(parsed).String()
+}
+
+func parsePublishedMeta(token html.Token) time.Time {
+	var key, content string
+
+	for _, attr := range token.Attr {
+		switch attr.Key {
+		case "property", "name", "itemprop":
+			key = strings.ToLower(attr.Val)
+		case "content":
+			content = strings.TrimSpace(attr.Val)
+		}
+	}
+
+	if content == "" || !support.Contains(publishedMeta, key) {
+		return time.Time{}
+	}
+
+	for _, layout := range publishedLayouts {
+		if published, err := time.Parse(layout, content); err == nil {
+			return published
+		}
+	}
+
+	return time.Time{}
+}
+
 func (f Result) FetchImage(ctx context.Context, target io.Writer) error {
 	if f.ImageURL == "" {
 		return ErrMissingImageURL
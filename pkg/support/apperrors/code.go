@@ -0,0 +1,18 @@
+package apperrors
+
+import "errors"
+
+// Code returns the error code of the first application error in the chain, empty for other errors.
+func Code(err error) string {
+	var coded interface{ Code() string }
+
+	if errors.As(err, &coded) {
+		return coded.Code()
+	}
+
+	return ""
+}
+
+func (e BusinessError) Code() string {
+	return e.ErrorCode
+}
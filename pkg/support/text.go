@@ -0,0 +1,44 @@
+package support
+
+import "strings"
+
+var accents = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ã", "a", "ä", "a",
+	"é", "e", "è", "e", "ê", "e", "ë", "e",
+	"í", "i", "ì", "i", "î", "i", "ï", "i",
+	"ó", "o", "ò", "o", "ô", "o", "õ", "o", "ö", "o",
+	"ú", "u", "ù", "u", "û", "u", "ü", "u",
+	"ç", "c", "ñ", "n",
+	"Á", "A", "À", "A", "Â", "A", "Ã", "A", "Ä", "A",
+	"É", "E", "È", "E", "Ê", "E", "Ë", "E",
+	"Í", "I", "Ì", "I", "Î", "I", "Ï", "I",
+	"Ó", "O", "Ò", "O", "Ô", "O", "Õ", "O", "Ö", "O",
+	"Ú", "U", "Ù", "U", "Û", "U", "Ü", "U",
+	"Ç", "C", "Ñ", "N",
+)
+
+// RemoveAccents replaces the accented letters of the latin languages, keeping the case.
+func RemoveAccents(text string) string {
+	return accents.Replace(text)
+}
+
+// Truncate text to limit runes, the last one is replaced by an ellipsis.
+// An empty text is returned when the limit is lower than 1.
+func Truncate(text string, limit int) string {
+	if limit < 1 {
+		return ""
+	}
+
+	runes := []rune(text)
+
+	if len(runes) <= limit {
+		return text
+	}
+
+	return string(runes[:limit-1]) + "…"
+}
+
+// Fold returns the text in lower case and without accents.
+func Fold(text string) string {
+	return strings.ToLower(accents.Replace(text))
+}
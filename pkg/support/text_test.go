@@ -0,0 +1,18 @@
+package support_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vinicius73/gear-feed/pkg/support"
+)
+
+func TestTruncate(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "ação", support.Truncate("ação", 4))
+	assert.Equal(t, "aç…", support.Truncate("ação", 3))
+	assert.Equal(t, "…", support.Truncate("ação", 1))
+	assert.Empty(t, support.Truncate("ação", 0))
+	assert.Empty(t, support.Truncate("ação", -10))
+}
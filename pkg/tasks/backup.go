@@ -60,6 +60,8 @@ func (t Backup[T]) Run(ctx context.Context, opts TaskRunOptions[T]) error {
 		return err
 	}
 
+	opts.Stats.Add("files", len(dataFiles))
+
 	err = opts.Sender.SendFile(ctx, sender.SendFileOptions{
 		FilePath: tmpFile.Name(),
 		Caption:  buildCaption(tmpFile.Name(), dataFiles),
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/rs/zerolog"
+	"github.com/vinicius73/gear-feed/pkg/metrics"
 	"github.com/vinicius73/gear-feed/pkg/model"
 	"github.com/vinicius73/gear-feed/pkg/sender"
 )
@@ -26,6 +27,9 @@ func (t Cleanup[T]) Run(ctx context.Context, opts TaskRunOptions[T]) error {
 		return err
 	}
 
+	metrics.CleanupEntries.Add(float64(count))
+	opts.Stats.Add("removed", int(count))
+
 	logger.Info().Int64("count", count).Msg("cleanup done")
 
 	if opts.Sender == nil && !t.Notify {
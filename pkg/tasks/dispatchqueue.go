@@ -0,0 +1,168 @@
+package tasks
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/vinicius73/gear-feed/pkg/model"
+	"github.com/vinicius73/gear-feed/pkg/storage"
+)
+
+var _ Task[model.IEntry] = (*DispatchQueue[model.IEntry])(nil)
+
+const (
+	defaultDispatchLimit    = 1
+	defaultDispatchMaxDelay = time.Hour * 12
+)
+
+// DispatchQueue sends the queued entries when their planned time is reached.
+type DispatchQueue[T model.IEntry] struct {
+	// Limit of entries sent by run, 1 by default.
+	Limit int `fig:"limit" yaml:"limit"`
+	// MaxDelay drops the entries planned before it, 12h by default, a negative value disables it.
+	MaxDelay time.Duration `fig:"max_delay" yaml:"max_delay"`
+	// QuietHours skips the runs, they are the quiet hours of the send_last_entries queue.
+	QuietHours QuietHours `fig:"-" yaml:"-"`
+}
+
+func (t DispatchQueue[T]) Name() string {
+	return "dispatch_queue"
+}
+
+func (t DispatchQueue[T]) Run(ctx context.Context, opts TaskRunOptions[T]) error {
+	logger := zerolog.Ctx(ctx)
+	now := time.Now()
+
+	quiet, err := t.QuietHours.Quiet(now)
+	if err != nil {
+		return err
+	}
+
+	if quiet {
+		logger.Info().Msg("quiet hours, skipping the queue")
+
+		return nil
+	}
+
+	items, err := opts.Storage.Queue(storage.FindQueueOptions{Until: now, Limit: 0})
+	if err != nil {
+		return err
+	}
+
+	due, expired, err := t.split(now, items)
+	if err != nil {
+		return err
+	}
+
+	opts.Stats.Add("expired", len(expired))
+
+	if len(due) == 0 {
+		logger.Info().Int("expired", len(expired)).Msg("no entries to dispatch")
+
+		return t.dequeue(ctx, opts, expired, nil)
+	}
+
+	// the entries sent by other tasks are dropped
+	pending, err := opts.Storage.Where(storage.WhereNotSent(opts.Sender.Destinations()...), due)
+	if err != nil {
+		return err
+	}
+
+	pendingHashes := map[string]bool{}
+
+	for _, entry := range pending {
+		hash, err := entry.Hash()
+		if err != nil {
+			return err
+		}
+
+		pendingHashes[hash] = true
+	}
+
+	done := expired
+	sent := 0
+
+	for _, entry := range due {
+		if sent >= t.limit() {
+			break
+		}
+
+		hash, err := entry.Hash()
+		if err != nil {
+			return err
+		}
+
+		if pendingHashes[hash] {
+			if err = opts.Sender.Send(ctx, entry); err != nil {
+				// the entries already sent are not sent again
+				return t.dequeue(ctx, opts, done, err)
+			}
+
+			sent++
+		}
+
+		done = append(done, hash)
+	}
+
+	opts.Stats.Add("sent", sent)
+
+	logger.Info().
+		Int("sent", sent).
+		Int("expired", len(expired)).
+		Msg("queue dispatched")
+
+	return t.dequeue(ctx, opts, done, nil)
+}
+
+// split returns the entries to send, in the planned order, and the hashes of the expired ones.
+func (t DispatchQueue[T]) split(now time.Time, items []storage.QueueItem[T]) ([]T, []string, error) {
+	due := []T{}
+	expired := []string{}
+	maxDelay := t.maxDelay()
+
+	for _, item := range items {
+		if maxDelay > 0 && item.PlannedAt.Before(now.Add(-maxDelay)) {
+			hash, err := item.Data.Hash()
+			if err != nil {
+				return nil, nil, err
+			}
+
+			expired = append(expired, hash)
+
+			continue
+		}
+
+		due = append(due, item.Data)
+	}
+
+	return due, expired, nil
+}
+
+func (t DispatchQueue[T]) dequeue(ctx context.Context, opts TaskRunOptions[T], hashes []string, err error) error {
+	if dequeueErr := opts.Storage.Dequeue(hashes...); dequeueErr != nil {
+		zerolog.Ctx(ctx).Error().Err(dequeueErr).Msg("failed to dequeue entries")
+
+		if err == nil {
+			err = dequeueErr
+		}
+	}
+
+	return err
+}
+
+func (t DispatchQueue[T]) limit() int {
+	if t.Limit <= 0 {
+		return defaultDispatchLimit
+	}
+
+	return t.Limit
+}
+
+func (t DispatchQueue[T]) maxDelay() time.Duration {
+	if t.MaxDelay == 0 {
+		return defaultDispatchMaxDelay
+	}
+
+	return t.MaxDelay
+}
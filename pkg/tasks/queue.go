@@ -0,0 +1,144 @@
+package tasks
+
+import (
+	"time"
+
+	"github.com/vinicius73/gear-feed/pkg/support/apperrors"
+)
+
+const defaultQueueInterval = time.Minute * 12
+
+var ErrInvalidQuietHours = apperrors.Business("invalid quiet hours %q, expected HH:MM", "TASKS:INVALID_QUIET_HOURS")
+
+// QueueConfig enqueues the entries instead of sending them, the dispatch_queue task sends them.
+type QueueConfig struct {
+	Enabled bool `fig:"enabled" yaml:"enabled"`
+	// Interval between the planned entries, 12 minutes by default.
+	Interval   time.Duration `fig:"interval"    yaml:"interval"`
+	QuietHours QuietHours    `fig:"quiet_hours" yaml:"quiet_hours"`
+}
+
+// QuietHours is a daily period without deliveries, like 23:00 to 07:00.
+type QuietHours struct {
+	Start string `fig:"start" yaml:"start"`
+	End   string `fig:"end"   yaml:"end"`
+	// Location of the hours, the local time is used when nil.
+	Location *time.Location `fig:"-" yaml:"-"`
+}
+
+func (c QueueConfig) interval() time.Duration {
+	if c.Interval <= 0 {
+		return defaultQueueInterval
+	}
+
+	return c.Interval
+}
+
+// Plan returns the send time of each entry, they are spaced by the interval after the last queued item.
+func (c QueueConfig) Plan(now, last time.Time, size int) ([]time.Time, error) {
+	interval := c.interval()
+	next := now
+
+	if after := last.Add(interval); !last.IsZero() && after.After(next) {
+		next = after
+	}
+
+	times := make([]time.Time, size)
+
+	for index := range times {
+		planned, err := c.QuietHours.Next(next)
+		if err != nil {
+			return nil, err
+		}
+
+		times[index] = planned
+		next = planned.Add(interval)
+	}
+
+	return times, nil
+}
+
+func (q QuietHours) Enabled() bool {
+	return q.Start != "" || q.End != ""
+}
+
+func (q QuietHours) Validate() error {
+	_, _, err := q.minutes()
+
+	return err
+}
+
+// Quiet reports if the time is inside the quiet hours.
+func (q QuietHours) Quiet(now time.Time) (bool, error) {
+	next, err := q.Next(now)
+
+	return !next.Equal(now), err
+}
+
+// Next returns the time itself, or the end of the quiet hours when the time is inside them.
+func (q QuietHours) Next(now time.Time) (time.Time, error) {
+	if !q.Enabled() {
+		return now, nil
+	}
+
+	start, end, err := q.minutes()
+	if err != nil {
+		return now, err
+	}
+
+	location := q.Location
+	if location == nil {
+		location = time.Local
+	}
+
+	local := now.In(location)
+	minute := local.Hour()*60 + local.Minute()
+
+	var quiet bool
+
+	if start <= end {
+		quiet = minute >= start && minute < end
+	} else {
+		// the period crosses midnight
+		quiet = minute >= start || minute < end
+	}
+
+	if !quiet {
+		return now, nil
+	}
+
+	endAt := time.Date(local.Year(), local.Month(), local.Day(), end/60, end%60, 0, 0, location)
+
+	if !endAt.After(local) {
+		endAt = endAt.AddDate(0, 0, 1)
+	}
+
+	return endAt.In(now.Location()), nil
+}
+
+func (q QuietHours) minutes() (int, int, error) {
+	if !q.Enabled() {
+		return 0, 0, nil
+	}
+
+	start, err := parseClock(q.Start)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	end, err := parseClock(q.End)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return start, end, nil
+}
+
+func parseClock(value string) (int, error) {
+	clock, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, ErrInvalidQuietHours.Msgf(value)
+	}
+
+	return clock.Hour()*60 + clock.Minute(), nil
+}
@@ -0,0 +1,143 @@
+package tasks_test
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vinicius73/gear-feed/pkg/model"
+	"github.com/vinicius73/gear-feed/pkg/sender"
+	"github.com/vinicius73/gear-feed/pkg/storage"
+	"github.com/vinicius73/gear-feed/pkg/storage/database"
+	"github.com/vinicius73/gear-feed/pkg/tasks"
+)
+
+func TestQuietHoursNext(t *testing.T) {
+	t.Parallel()
+
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2024, 9, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	overnight := tasks.QuietHours{Start: "23:00", End: "07:00", Location: time.UTC}
+	afternoon := tasks.QuietHours{Start: "12:00", End: "14:30", Location: time.UTC}
+
+	tests := []struct {
+		name  string
+		quiet tasks.QuietHours
+		now   time.Time
+		want  time.Time
+	}{
+		{name: "disabled", quiet: tasks.QuietHours{}, now: at(1, 23, 30), want: at(1, 23, 30)},
+		{name: "before midnight", quiet: overnight, now: at(1, 23, 30), want: at(2, 7, 0)},
+		{name: "after midnight", quiet: overnight, now: at(2, 3, 0), want: at(2, 7, 0)},
+		{name: "active hours", quiet: overnight, now: at(2, 7, 0), want: at(2, 7, 0)},
+		{name: "same day", quiet: afternoon, now: at(2, 13, 0), want: at(2, 14, 30)},
+		{name: "outside same day", quiet: afternoon, now: at(2, 15, 0), want: at(2, 15, 0)},
+	}
+
+	for _, test := range tests {
+		next, err := test.quiet.Next(test.now)
+		require.NoError(t, err, test.name)
+		assert.Equal(t, test.want, next, test.name)
+	}
+
+	_, err := tasks.QuietHours{Start: "25:00", End: "07:00", Location: nil}.Next(at(1, 0, 0))
+	assert.ErrorIs(t, err, tasks.ErrInvalidQuietHours.Msgf("25:00"))
+}
+
+func TestQueueConfigPlan(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 9, 1, 22, 30, 0, 0, time.UTC)
+	config := tasks.QueueConfig{
+		Enabled:    true,
+		Interval:   time.Minute * 15,
+		QuietHours: tasks.QuietHours{Start: "23:00", End: "07:00", Location: time.UTC},
+	}
+
+	times, err := config.Plan(now, time.Time{}, 4)
+	require.NoError(t, err)
+	assert.Equal(t, []time.Time{
+		now,
+		now.Add(time.Minute * 15),
+		time.Date(2024, 9, 2, 7, 0, 0, 0, time.UTC),
+		time.Date(2024, 9, 2, 7, 15, 0, 0, time.UTC),
+	}, times)
+
+	// after the last queued entry
+	times, err = config.Plan(now, now.Add(time.Minute*5), 1)
+	require.NoError(t, err)
+	assert.Equal(t, []time.Time{now.Add(time.Minute * 20)}, times)
+}
+
+func TestDispatchQueue(t *testing.T) {
+	t.Parallel()
+
+	opts := database.Options{
+		Options: storage.Options{TTL: time.Hour},
+		Path:    filepath.Join(t.TempDir(), "test.sqlite"),
+	}
+
+	db, err := database.Open(context.TODO(), opts)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { db.Close() })
+
+	store, err := database.NewStorage[model.Entry](db, opts)
+	require.NoError(t, err)
+
+	out := &bytes.Buffer{}
+
+	serder, err := sender.NewDryRunSerder(sender.DryRunOptions[model.Entry]{
+		Chats:       []int64{},
+		Output:      out,
+		Dir:         "",
+		Destination: "test",
+		Store:       true,
+		Storage:     store,
+	})
+	require.NoError(t, err)
+
+	entry := func(name string) model.Entry {
+		return model.Entry{Title: "Entry " + name, URL: "https://foo.com/" + name, SourceName: "FOO"}
+	}
+
+	now := time.Now()
+
+	_, err = store.Enqueue(
+		storage.QueueItem[model.Entry]{Data: entry("expired"), PlannedAt: now.Add(-time.Hour * 24)},
+		storage.QueueItem[model.Entry]{Data: entry("sent"), PlannedAt: now.Add(-time.Minute * 10)},
+		storage.QueueItem[model.Entry]{Data: entry("due"), PlannedAt: now.Add(-time.Minute * 5)},
+		storage.QueueItem[model.Entry]{Data: entry("late"), PlannedAt: now.Add(-time.Minute)},
+		storage.QueueItem[model.Entry]{Data: entry("future"), PlannedAt: now.Add(time.Hour)},
+	)
+	require.NoError(t, err)
+
+	// sent by another task
+	require.NoError(t, serder.Send(context.TODO(), entry("sent")))
+	out.Reset()
+
+	stats := tasks.NewStats()
+	task := tasks.DispatchQueue[model.Entry]{Limit: 1, MaxDelay: 0, QuietHours: tasks.QuietHours{}}
+
+	require.NoError(t, task.Run(context.TODO(), tasks.TaskRunOptions[model.Entry]{
+		Storage: store,
+		Sender:  serder,
+		Stats:   stats,
+	}))
+
+	assert.Contains(t, out.String(), "Entry due")
+	assert.NotContains(t, out.String(), "Entry late")
+	assert.Equal(t, map[string]int{"sent": 1, "expired": 1}, stats.Counters())
+
+	items, err := store.Queue(storage.FindQueueOptions{})
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.Equal(t, "Entry late", items[0].Data.Title)
+	assert.Equal(t, "Entry future", items[1].Data.Title)
+}
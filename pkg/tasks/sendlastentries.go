@@ -2,13 +2,16 @@ package tasks
 
 import (
 	"context"
+	"time"
 
 	"github.com/rs/zerolog"
+	"github.com/vinicius73/gear-feed/pkg/filters"
 	"github.com/vinicius73/gear-feed/pkg/linkloader"
 	"github.com/vinicius73/gear-feed/pkg/linkloader/news"
 	"github.com/vinicius73/gear-feed/pkg/model"
 	"github.com/vinicius73/gear-feed/pkg/sender"
 	"github.com/vinicius73/gear-feed/pkg/sources"
+	"github.com/vinicius73/gear-feed/pkg/storage"
 )
 
 var _ Task[model.IEntry] = (*SendLastEntries[model.IEntry])(nil)
@@ -16,9 +19,15 @@ var _ Task[model.IEntry] = (*SendLastEntries[model.IEntry])(nil)
 const defaultSendLastEntriesLimit = 10
 
 type SendLastEntries[T model.IEntry] struct {
-	Limit        int                 `fig:"limit"          yaml:"limit"`
-	SendResumeTo []int64             `fig:"send_resume_to" yaml:"send_resume_to"`
-	Sources      sources.LoadOptions `fig:"sources"        yaml:"sources"`
+	Limit        int                  `fig:"limit"          yaml:"limit"`
+	MaxPerSource int                  `fig:"max_per_source" yaml:"max_per_source"`
+	MaxAge       time.Duration        `fig:"max_age"        yaml:"max_age"`
+	SendResumeTo []int64              `fig:"send_resume_to" yaml:"send_resume_to"`
+	Sources      sources.LoadOptions  `fig:"sources"        yaml:"sources"`
+	Health       sources.HealthConfig `fig:"health"         yaml:"health"`
+	Dedup        news.DedupConfig     `fig:"dedup"          yaml:"dedup"`
+	Filters      filters.Filters      `fig:"filters"        yaml:"filters"`
+	Queue        QueueConfig          `fig:"queue"          yaml:"queue"`
 }
 
 func (t SendLastEntries[T]) Name() string {
@@ -26,6 +35,14 @@ func (t SendLastEntries[T]) Name() string {
 }
 
 func (t SendLastEntries[T]) Run(ctx context.Context, opts TaskRunOptions[T]) error {
+	if err := t.Filters.Validate(); err != nil {
+		return err
+	}
+
+	if err := t.Queue.QuietHours.Validate(); err != nil {
+		return err
+	}
+
 	definitions, err := sources.Load(ctx, t.Sources)
 	if err != nil {
 		return err
@@ -45,16 +62,37 @@ func (t SendLastEntries[T]) Run(ctx context.Context, opts TaskRunOptions[T]) err
 			Sources: definitions,
 			Workers: 0, // dynamic
 		},
-		Limit:   limit,
-		Storage: opts.Storage,
+		Limit:        limit,
+		MaxPerSource: t.MaxPerSource,
+		Seed:         0, // random
+		MaxAge:       t.MaxAge,
+		Storage:      opts.Storage,
+		Destinations: opts.Sender.Destinations(),
+		Health:       t.Health,
+		Dedup:        t.Dedup,
+		Filters:      t.Filters,
+		SkipQueued:   t.Queue.Enabled,
 	})
 	if err != nil {
 		return err
 	}
 
-	if len(entries.Entries) == 0 {
+	opts.Stats.Add("loaded", entries.Loaded)
+	opts.Stats.Add("filtered", entries.Filtered)
+	opts.Stats.Add("duplicates", entries.Duplicates)
+	opts.Stats.Add("failed_sources", len(entries.Failed))
+	opts.Stats.Add("quarantined_sources", len(entries.Quarantined))
+
+	switch {
+	case len(entries.Entries) == 0:
 		zerolog.Ctx(ctx).Info().Msg("no entries to send")
-	} else if err = opts.Sender.SendCollection(ctx, entries.Entries); err != nil {
+	case t.Queue.Enabled:
+		err = t.enqueue(ctx, entries.Entries, opts)
+	default:
+		err = opts.Sender.SendCollection(ctx, entries.Entries)
+	}
+
+	if err != nil {
 		return err
 	}
 
@@ -67,23 +105,52 @@ func (t SendLastEntries[T]) Run(ctx context.Context, opts TaskRunOptions[T]) err
 	return nil
 }
 
-func (t SendLastEntries[T]) sendResume(ctx context.Context, entries news.Result[T], opts TaskRunOptions[T]) error {
-	sources := make([]sender.ResumeSource, len(entries.Results))
+// enqueue plans the entries after the ones already queued.
+func (t SendLastEntries[T]) enqueue(ctx context.Context, entries []T, opts TaskRunOptions[T]) error {
+	queued, err := opts.Storage.Queue(storage.FindQueueOptions{Until: time.Time{}, Limit: 0})
+	if err != nil {
+		return err
+	}
+
+	last := time.Time{}
+
+	if len(queued) > 0 {
+		last = queued[len(queued)-1].PlannedAt
+	}
 
-	for index, result := range entries.Results {
-		sources[index] = sender.ResumeSource{
-			Source:   result.Source,
-			Loaded:   result.Total,
-			Filtered: result.Filtered,
+	times, err := t.Queue.Plan(time.Now(), last, len(entries))
+	if err != nil {
+		return err
+	}
+
+	items := make([]storage.QueueItem[T], len(entries))
+
+	for index, entry := range entries {
+		items[index] = storage.QueueItem[T]{
+			Data:      entry,
+			PlannedAt: times[index],
+			CreatedAt: time.Now(),
 		}
 	}
 
+	added, err := opts.Storage.Enqueue(items...)
+	if err != nil {
+		return err
+	}
+
+	opts.Stats.Add("queued", added)
+
+	zerolog.Ctx(ctx).Info().
+		Int("queued", added).
+		Time("until", times[len(times)-1]).
+		Msg("entries queued")
+
+	return nil
+}
+
+func (t SendLastEntries[T]) sendResume(ctx context.Context, entries news.Result[T], opts TaskRunOptions[T]) error {
 	return opts.Sender.SendResume(ctx, sender.SendResumeOptions{
-		Chats: t.SendResumeTo,
-		Resume: sender.Resume{
-			Loaded:   entries.Loaded,
-			Filtered: entries.Filtered,
-			Sources:  sources,
-		},
+		Chats:  t.SendResumeTo,
+		Resume: entries.Resume(),
 	})
 }
@@ -32,6 +32,8 @@ func (t SendLastStories[T]) Run(ctx context.Context, opts TaskRunOptions[T]) err
 		return err
 	}
 
+	opts.Stats.Add("loaded", len(entries))
+
 	if len(entries) == 0 {
 		logger.Warn().Msg("no entries to send")
 
@@ -43,6 +45,8 @@ func (t SendLastStories[T]) Run(ctx context.Context, opts TaskRunOptions[T]) err
 		return err
 	}
 
+	opts.Stats.Add("built", len(stories))
+
 	defer removeAll()
 
 	for _, story := range stories {
@@ -0,0 +1,51 @@
+package tasks
+
+import (
+	"maps"
+	"sync"
+
+	"github.com/vinicius73/gear-feed/pkg/sender"
+)
+
+// Stats counts what a task did, a nil Stats ignores the counters.
+type Stats struct {
+	mu       sync.Mutex
+	counters map[string]int
+}
+
+func NewStats() *Stats {
+	return &Stats{mu: sync.Mutex{}, counters: map[string]int{}}
+}
+
+func (s *Stats) Add(name string, val int) {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.counters[name] += val
+}
+
+func (s *Stats) Counters() map[string]int {
+	if s == nil {
+		return map[string]int{}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return maps.Clone(s.counters)
+}
+
+// Observe counts the deliveries, like message_sent and story_failed.
+func (s *Stats) Observe(kind sender.Kind, err error) {
+	if err != nil {
+		s.Add(string(kind)+"_failed", 1)
+
+		return
+	}
+
+	s.Add(string(kind)+"_sent", 1)
+}
@@ -11,6 +11,8 @@ import (
 type TaskRunOptions[T model.IEntry] struct {
 	Storage storage.Storage[T]
 	Sender  sender.Serder[T]
+	// Stats is optional, it receives the counters of the run.
+	Stats *Stats
 }
 
 type Task[T model.IEntry] interface {
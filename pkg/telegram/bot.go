@@ -13,6 +13,8 @@ const poolingTiming = 10 * time.Second
 type Config struct {
 	Token     string  `fig:"token"     yaml:"token"`
 	Broadcast []int64 `fig:"broadcast" yaml:"broadcast"`
+	// Admins are the chats allowed to use the bot commands.
+	Admins []int64 `fig:"admins" yaml:"admins"`
 }
 
 const LoggerKey = "bot:logger"
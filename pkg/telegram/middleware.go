@@ -0,0 +1,48 @@
+package telegram
+
+import (
+	"github.com/rs/zerolog"
+	"github.com/vinicius73/gear-feed/pkg/support"
+	"gopkg.in/telebot.v3"
+)
+
+// WithLogger injects the logger used by handlers and by the error handler.
+func WithLogger(logger zerolog.Logger) telebot.MiddlewareFunc {
+	return func(next telebot.HandlerFunc) telebot.HandlerFunc {
+		return func(c telebot.Context) error {
+			log := logger.With().
+				Int64("chat", chatID(c)).
+				Str("text", c.Text()).
+				Logger()
+
+			c.Set(LoggerKey, log)
+
+			return next(c)
+		}
+	}
+}
+
+// OnlyChats ignores updates from chats or users not present in ids.
+func OnlyChats(ids []int64) telebot.MiddlewareFunc {
+	return func(next telebot.HandlerFunc) telebot.HandlerFunc {
+		return func(c telebot.Context) error {
+			if support.Contains(ids, chatID(c)) || (c.Sender() != nil && support.Contains(ids, c.Sender().ID)) {
+				return next(c)
+			}
+
+			if logger, ok := c.Get(LoggerKey).(zerolog.Logger); ok {
+				logger.Warn().Msg("Ignoring command from unauthorized chat")
+			}
+
+			return nil
+		}
+	}
+}
+
+func chatID(c telebot.Context) int64 {
+	if chat := c.Chat(); chat != nil {
+		return chat.ID
+	}
+
+	return 0
+}
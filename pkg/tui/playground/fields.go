@@ -0,0 +1,156 @@
+package playground
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/vinicius73/gear-feed/pkg/filters"
+	"github.com/vinicius73/gear-feed/pkg/scraper"
+	"github.com/vinicius73/gear-feed/pkg/support"
+)
+
+const inputWidth = 40
+
+type field struct {
+	key   string
+	input textinput.Model
+}
+
+// fieldKeys follow the yaml keys of the source definition.
+var fieldKeys = []string{
+	"name",
+	"parser",
+	"entry_selector",
+	"link.path",
+	"link.attribute",
+	"link.parse_strategy",
+	"title.path",
+	"title.attribute",
+	"image.path",
+	"image.attribute",
+	"image.parse_strategy",
+	"category.path",
+	"category.attribute",
+	"category.allows",
+}
+
+func newFields(source scraper.SourceDefinition) []field {
+	values := map[string]string{
+		"name":                 source.Name,
+		"parser":               source.Parser,
+		"entry_selector":       source.Attributes.EntrySelector,
+		"link.path":            source.Attributes.Link.Path,
+		"link.attribute":       source.Attributes.Link.Attribute,
+		"link.parse_strategy":  source.Attributes.Link.ParseStrategy,
+		"title.path":           source.Attributes.Title.Path,
+		"title.attribute":      source.Attributes.Title.Attribute,
+		"image.path":           source.Attributes.Image.Path,
+		"image.attribute":      source.Attributes.Image.Attribute,
+		"image.parse_strategy": source.Attributes.Image.ParseStrategy,
+		"category.path":        source.Attributes.Category.Path,
+		"category.attribute":   source.Attributes.Category.Attribute,
+		"category.allows":      strings.Join(source.Attributes.Category.Alloweds, ", "),
+	}
+
+	fields := make([]field, len(fieldKeys))
+
+	for index, key := range fieldKeys {
+		input := textinput.New()
+		input.Prompt = ""
+		input.Width = inputWidth
+		input.SetValue(values[key])
+
+		fields[index] = field{key: key, input: input}
+	}
+
+	return fields
+}
+
+// definition builds the source with the current values of the fields.
+func definition(base scraper.SourceDefinition, fields []field) scraper.SourceDefinition {
+	values := map[string]string{}
+
+	for _, field := range fields {
+		values[field.key] = strings.TrimSpace(field.input.Value())
+	}
+
+	source := base
+	source.Name = values["name"]
+	source.Parser = strings.ToUpper(values["parser"])
+	source.Attributes.EntrySelector = values["entry_selector"]
+	source.Attributes.Link = scraper.PathFinder{
+		Path:          values["link.path"],
+		Attribute:     values["link.attribute"],
+		ParseStrategy: values["link.parse_strategy"],
+	}
+	source.Attributes.Title = scraper.PathFinder{
+		Path:          values["title.path"],
+		Attribute:     values["title.attribute"],
+		ParseStrategy: source.Attributes.Title.ParseStrategy,
+	}
+	source.Attributes.Image = scraper.PathFinder{
+		Path:          values["image.path"],
+		Attribute:     values["image.attribute"],
+		ParseStrategy: values["image.parse_strategy"],
+	}
+	source.Attributes.Category = scraper.PathFinderCategory{
+		PathFinder: scraper.PathFinder{
+			Path:          values["category.path"],
+			Attribute:     values["category.attribute"],
+			ParseStrategy: source.Attributes.Category.ParseStrategy,
+		},
+		Alloweds: splitList(values["category.allows"]),
+	}
+
+	return source
+}
+
+// NewSource builds a source definition for the page URL.
+func NewSource(page string, parser string) (scraper.SourceDefinition, error) {
+	parsed, err := url.Parse(page)
+	if err != nil {
+		return scraper.SourceDefinition{}, err
+	}
+
+	path := parsed.EscapedPath()
+
+	if parsed.RawQuery != "" {
+		path += "?" + parsed.RawQuery
+	}
+
+	if path == "" {
+		path = "/"
+	}
+
+	return scraper.SourceDefinition{
+		Name:           strings.ToUpper(strings.ReplaceAll(strings.TrimPrefix(parsed.Hostname(), "www."), ".", "_")),
+		Enabled:        true,
+		SupportStories: false,
+		BaseURL:        parsed.Scheme + "://" + parsed.Host,
+		Paths:          []string{path},
+		Limit:          0,
+		Parser:         parser,
+		Weight:         0,
+		Priority:       0,
+		StripParams:    []string{},
+		Fetcher:        "",
+		WaitFor:        "",
+		Pagination:     scraper.Pagination{},
+		Enrich:         scraper.Enrich{},
+		Filters:        filters.Filters{},
+		Attributes:     scraper.AttributesFinder{},
+	}, nil
+}
+
+func splitList(value string) []string {
+	list := []string{}
+
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+
+	return support.ToLower(list)
+}
@@ -0,0 +1,275 @@
+package playground
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/vinicius73/gear-feed/pkg/model"
+	"github.com/vinicius73/gear-feed/pkg/scraper"
+	"github.com/vinicius73/gear-feed/pkg/sources"
+	"github.com/vinicius73/gear-feed/pkg/tui"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	loadTimeout = time.Second * 15
+	labelWidth  = 22
+	filePerm    = 0o644
+)
+
+var (
+	labelStyle   = lipgloss.NewStyle().Width(labelWidth).Foreground(lipgloss.Color("#A49FA5"))
+	focusStyle   = labelStyle.Foreground(lipgloss.Color("205"))
+	previewStyle = lipgloss.NewStyle().PaddingLeft(2)
+	mutedStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("#777777"))
+)
+
+type keyMap struct {
+	next key.Binding
+	prev key.Binding
+	save key.Binding
+	quit key.Binding
+}
+
+var keys = keyMap{
+	next: key.NewBinding(key.WithKeys("tab", "down"), key.WithHelp("tab", "next field")),
+	prev: key.NewBinding(key.WithKeys("shift+tab", "up"), key.WithHelp("shift+tab", "previous field")),
+	save: key.NewBinding(key.WithKeys("ctrl+s"), key.WithHelp("ctrl+s", "save")),
+	quit: key.NewBinding(key.WithKeys("esc", "ctrl+c"), key.WithHelp("esc", "quit")),
+}
+
+type extractedMsg struct {
+	revision int
+	entries  []model.Entry
+	err      error
+}
+
+type Options struct {
+	Source scraper.SourceDefinition
+	// Output is the file written by the save key, the source name is used when empty.
+	Output string
+}
+
+// Model fetches the source page once and extracts the entries again on each change.
+//
+//nolint:containedctx
+type Model struct {
+	ctx      context.Context
+	base     scraper.SourceDefinition
+	output   string
+	fields   []field
+	focus    int
+	revision int
+	loading  bool
+	entries  []model.Entry
+	err      error
+	status   string
+}
+
+func New(ctx context.Context, opt Options) Model {
+	ctx = scraper.WithoutDelay(scraper.WithTransport(ctx, newCache()))
+
+	fields := newFields(opt.Source)
+	fields[0].input.Focus()
+
+	return Model{
+		ctx:      ctx,
+		base:     opt.Source,
+		output:   opt.Output,
+		fields:   fields,
+		focus:    0,
+		revision: 0,
+		loading:  true,
+		entries:  []model.Entry{},
+		err:      nil,
+		status:   "",
+	}
+}
+
+func (m Model) Init() tea.Cmd {
+	return m.extract()
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		tui.SetWindowSize(msg.Width, msg.Height)
+
+		return m, nil
+	case extractedMsg:
+		// ignore the results of outdated definitions
+		if msg.revision == m.revision {
+			m.loading = false
+			m.entries = msg.entries
+			m.err = msg.err
+		}
+
+		return m, nil
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, keys.quit):
+			return m, tea.Quit
+		case key.Matches(msg, keys.save):
+			m.status = m.save()
+
+			return m, nil
+		case key.Matches(msg, keys.next):
+			return m, m.move(1)
+		case key.Matches(msg, keys.prev):
+			return m, m.move(-1)
+		}
+	}
+
+	before := m.fields[m.focus].input.Value()
+
+	var cmd tea.Cmd
+
+	m.fields[m.focus].input, cmd = m.fields[m.focus].input.Update(msg)
+
+	if m.fields[m.focus].input.Value() == before {
+		return m, cmd
+	}
+
+	m.revision++
+	m.loading = true
+	m.status = ""
+
+	return m, tea.Batch(cmd, m.extract())
+}
+
+func (m *Model) move(step int) tea.Cmd {
+	m.fields[m.focus].input.Blur()
+	m.focus = (m.focus + step + len(m.fields)) % len(m.fields)
+
+	return m.fields[m.focus].input.Focus()
+}
+
+func (m Model) source() scraper.SourceDefinition {
+	return definition(m.base, m.fields)
+}
+
+func (m Model) extract() tea.Cmd {
+	source := m.source()
+	revision := m.revision
+	parent := m.ctx
+
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(parent, loadTimeout)
+		defer cancel()
+
+		entries, err := scraper.FindEntries[model.Entry](ctx, source)
+
+		return extractedMsg{revision: revision, entries: entries, err: err}
+	}
+}
+
+// save writes the source definition when it is valid, the result is shown in the status line.
+func (m Model) save() string {
+	source := m.source()
+	output := m.output
+
+	if output == "" {
+		output = source.Name + ".yml"
+	}
+
+	var buffer bytes.Buffer
+
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(2) //nolint:gomnd
+
+	if err := encoder.Encode(source); err != nil {
+		return "fail to encode: " + err.Error()
+	}
+
+	content := buffer.Bytes()
+
+	if problems := sources.ValidateDefinition(output, content); len(problems) > 0 {
+		return "not saved, " + problems[0].String()
+	}
+
+	if err := os.WriteFile(output, content, filePerm); err != nil {
+		return "fail to save: " + err.Error()
+	}
+
+	return "saved " + output
+}
+
+func (m Model) View() string {
+	size := tui.GetWindowSize()
+
+	var form strings.Builder
+
+	form.WriteString(tui.TitleStyle.Render("Playground") + " " + mutedStyle.Render(m.base.BaseURL+strings.Join(m.base.Paths, ",")))
+	form.WriteString("\n\n")
+
+	for index, field := range m.fields {
+		label := labelStyle
+
+		if index == m.focus {
+			label = focusStyle
+		}
+
+		form.WriteString(label.Render(field.key) + field.input.View() + "\n")
+	}
+
+	form.WriteString("\n" + mutedStyle.Render(helpLine()))
+
+	if m.status != "" {
+		form.WriteString("\n" + tui.StatusMessageStyle.Render(m.status))
+	}
+
+	preview := previewStyle.Width(max(size.Width-labelWidth-inputWidth-4, 20)).Render(m.preview(size.Height - 2))
+
+	return tui.AppStyle.Render(lipgloss.JoinHorizontal(lipgloss.Top, form.String(), preview))
+}
+
+func (m Model) preview(height int) string {
+	var builder strings.Builder
+
+	switch {
+	case m.loading:
+		builder.WriteString("extracting...\n")
+	case m.err != nil:
+		return tui.ErrorStyle.Render(m.err.Error())
+	default:
+		builder.WriteString(strconv.Itoa(len(m.entries)) + " entries\n")
+	}
+
+	lines := 1
+
+	for index, entry := range m.entries {
+		// each entry takes 4 lines
+		if height > 0 && lines+4 > height {
+			builder.WriteString(mutedStyle.Render(fmt.Sprintf("... %d more", len(m.entries)-index)))
+
+			break
+		}
+
+		builder.WriteString(fmt.Sprintf("\n%d. %s\n", index+1, entry.Title))
+		builder.WriteString(mutedStyle.Render("   "+entry.URL) + "\n")
+		builder.WriteString(mutedStyle.Render("   "+entry.Image+" ["+strings.Join(entry.Categories, ", ")+"]") + "\n")
+
+		lines += 4
+	}
+
+	return builder.String()
+}
+
+func helpLine() string {
+	bindings := []key.Binding{keys.next, keys.prev, keys.save, keys.quit}
+	help := make([]string, len(bindings))
+
+	for index, binding := range bindings {
+		help[index] = binding.Help().Key + " " + binding.Help().Desc
+	}
+
+	return strings.Join(help, " • ")
+}
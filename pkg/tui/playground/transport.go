@@ -0,0 +1,72 @@
+package playground
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"sync"
+)
+
+type cachedResponse struct {
+	status int
+	header http.Header
+	body   []byte
+}
+
+// cache fetches each URL once, the next extractions use the saved response.
+type cache struct {
+	base      http.RoundTripper
+	mu        *sync.Mutex
+	responses map[string]cachedResponse
+}
+
+func newCache() cache {
+	return cache{
+		base:      http.DefaultTransport,
+		mu:        &sync.Mutex{},
+		responses: map[string]cachedResponse{},
+	}
+}
+
+func (c cache) RoundTrip(req *http.Request) (*http.Response, error) {
+	// the lock is kept during the request, so concurrent extractions wait for the first fetch
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	url := req.URL.String()
+
+	if cached, found := c.responses[url]; found {
+		return cached.response(req), nil
+	}
+
+	res, err := c.base.RoundTrip(req)
+	if err != nil {
+		return res, err
+	}
+
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	cached := cachedResponse{status: res.StatusCode, header: res.Header.Clone(), body: body}
+	c.responses[url] = cached
+
+	return cached.response(req), nil
+}
+
+func (r cachedResponse) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        http.StatusText(r.status),
+		StatusCode:    r.status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        r.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(r.body)),
+		ContentLength: int64(len(r.body)),
+		Request:       req,
+	}
+}
@@ -1,16 +1,55 @@
 package sourcelist
 
 import (
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/vinicius73/gear-feed/pkg/scraper"
+	"github.com/vinicius73/gear-feed/pkg/storage"
 )
 
 var _ list.DefaultItem = (*SourceItem)(nil)
 
 type SourceItem struct {
 	scraper.SourceDefinition
+	Health storage.SourceHealth
 }
 
 func (i SourceItem) Title() string       { return i.Name }
-func (i SourceItem) Description() string { return i.BaseURL }
+func (i SourceItem) Description() string { return i.BaseURL + " " + i.status() }
 func (i SourceItem) FilterValue() string { return i.Name }
+
+func (i SourceItem) status() string {
+	health := i.Health
+
+	if health.UpdatedAt.IsZero() {
+		return "· no runs"
+	}
+
+	parts := []string{}
+
+	switch {
+	case health.Quarantined(time.Now()):
+		parts = append(parts, "🚧 quarantined until "+health.QuarantinedUntil.Format(time.DateTime))
+	case health.ConsecutiveFailures > 0:
+		parts = append(parts, "❌ "+strconv.Itoa(health.ConsecutiveFailures)+" failures")
+	default:
+		parts = append(parts, "✅ ok")
+	}
+
+	if !health.LastSuccess.IsZero() {
+		parts = append(parts, "last success "+health.LastSuccess.Format(time.DateTime))
+	}
+
+	if health.ZeroEntryRuns > 0 {
+		parts = append(parts, strconv.Itoa(health.ZeroEntryRuns)+" empty runs")
+	}
+
+	if health.CloudflareHits > 0 {
+		parts = append(parts, strconv.Itoa(health.CloudflareHits)+" cloudflare hits")
+	}
+
+	return "· " + strings.Join(parts, " · ")
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/vinicius73/gear-feed/pkg/scraper"
+	"github.com/vinicius73/gear-feed/pkg/storage"
 	"github.com/vinicius73/gear-feed/pkg/tui"
 )
 
@@ -12,11 +13,11 @@ type Model struct {
 	delegateKeys *delegateKeyMap
 }
 
-func New(sources []scraper.SourceDefinition) Model {
+func New(sources []scraper.SourceDefinition, health map[string]storage.SourceHealth) Model {
 	itens := make([]list.Item, len(sources))
 
 	for i, source := range sources {
-		itens[i] = SourceItem{source}
+		itens[i] = SourceItem{source, health[source.Name]}
 	}
 
 	delegateKeys := newDelegateKeyMap()
@@ -5,6 +5,7 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/vinicius73/gear-feed/pkg/scraper"
+	"github.com/vinicius73/gear-feed/pkg/storage"
 	"github.com/vinicius73/gear-feed/pkg/tui"
 	"github.com/vinicius73/gear-feed/pkg/tui/loadlinks"
 	"github.com/vinicius73/gear-feed/pkg/tui/sourcelist"
@@ -27,11 +28,11 @@ type Model struct {
 	quitting bool
 }
 
-func NewModel(ctx context.Context, sources []scraper.SourceDefinition) Model {
+func NewModel(ctx context.Context, sources []scraper.SourceDefinition, health map[string]storage.SourceHealth) Model {
 	return Model{
 		ctx:      ctx,
 		mode:     listMode,
-		list:     sourcelist.New(sources),
+		list:     sourcelist.New(sources, health),
 		quitting: false,
 		links:    nil,
 		err:      nil,